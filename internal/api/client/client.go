@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/compozy/compozy/internal/api/contract"
@@ -61,11 +62,16 @@ func (t Target) String() string {
 	return "unknown"
 }
 
+// defaultReadRetryDelays is the backoff between attempts of a read request
+// while the daemon refuses connections, which covers a daemon restart.
+var defaultReadRetryDelays = []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second}
+
 // Client issues daemon API requests over UDS by default with localhost HTTP fallback.
 type Client struct {
-	target     Target
-	baseURL    string
-	httpClient *http.Client
+	target          Target
+	baseURL         string
+	httpClient      *http.Client
+	readRetryDelays []time.Duration
 }
 
 // RemoteError captures the daemon's non-2xx transport error envelope.
@@ -108,14 +114,16 @@ func New(target Target) (*Client, error) {
 			httpClient: &http.Client{
 				Transport: transport,
 			},
+			readRetryDelays: defaultReadRetryDelays,
 		}, nil
 	}
 
 	baseURL := "http://127.0.0.1:" + strconv.Itoa(target.HTTPPort)
 	return &Client{
-		target:     target,
-		baseURL:    baseURL,
-		httpClient: &http.Client{},
+		target:          target,
+		baseURL:         baseURL,
+		httpClient:      &http.Client{},
+		readRetryDelays: defaultReadRetryDelays,
 	}, nil
 }
 
@@ -255,20 +263,32 @@ func (c *Client) doJSON(
 		return 0, err
 	}
 
-	request, err := http.NewRequestWithContext(ctx, method, c.baseURL, bodyReader)
-	if err != nil {
-		return 0, fmt.Errorf("build daemon request: %w", err)
-	}
-	if err := applyRequestPath(request, path); err != nil {
-		return 0, err
-	}
-	if hasBody {
-		request.Header.Set("Content-Type", "application/json")
-	}
+	var (
+		statusCode int
+		payload    []byte
+	)
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, method, c.baseURL, bodyReader)
+		if err != nil {
+			return 0, fmt.Errorf("build daemon request: %w", err)
+		}
+		if err := applyRequestPath(request, path); err != nil {
+			return 0, err
+		}
+		if hasBody {
+			request.Header.Set("Content-Type", "application/json")
+		}
 
-	statusCode, payload, err := c.doRequest(request)
-	if err != nil {
-		return statusCode, err
+		statusCode, payload, err = c.doRequest(request)
+		if err == nil {
+			break
+		}
+		if !c.shouldRetryRead(method, path, attempt, err) {
+			return statusCode, err
+		}
+		if waitErr := sleepWithContext(ctx, c.readRetryDelays[attempt]); waitErr != nil {
+			return statusCode, err
+		}
 	}
 
 	if err := c.handleStatus(path, statusCode, payload, responseBody); err != nil {
@@ -280,6 +300,30 @@ func (c *Client) doJSON(
 	return statusCode, nil
 }
 
+// shouldRetryRead reports whether a failed request may be sent again. Only
+// reads are retried, and only while the daemon refuses connections. Health
+// probes fail fast so that bootstrap can start a missing daemon.
+func (c *Client) shouldRetryRead(method string, path string, attempt int, err error) bool {
+	if method != http.MethodGet || attempt >= len(c.readRetryDelays) {
+		return false
+	}
+	if strings.HasSuffix(path, "/daemon/health") {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ENOENT)
+}
+
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func marshalRequestBody(requestBody any) (io.Reader, bool, error) {
 	if requestBody == nil {
 		return nil, false, nil
@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -555,6 +557,7 @@ func TestClientRunQueriesUseCanonicalContract(t *testing.T) {
 					if query.Get("workspace") != "/tmp/workspace" ||
 						query.Get("status") != "pending,running" ||
 						query.Get("mode") != "exec" ||
+						!slices.Equal(query["label"], []string{"env=prod", "team=core"}) ||
 						query.Get("limit") != "25" {
 						t.Fatalf("run list query = %#v, want canonical filters", query)
 					}
@@ -586,6 +589,7 @@ func TestClientRunQueriesUseCanonicalContract(t *testing.T) {
 		Workspace: "/tmp/workspace",
 		Statuses:  []string{" pending ", "running"},
 		Mode:      "exec",
+		Labels:    map[string]string{"team": "core", "env": "prod"},
 		Limit:     25,
 	})
 	if err != nil {
@@ -909,6 +913,74 @@ func TestNilReceiverAndUtilityGuards(t *testing.T) {
 	resetStreamTimer(nil, time.Second)
 }
 
+func TestClientRetriesReadsWhileDaemonRefusesConnections(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(calls *int, refusals int) *Client {
+		return &Client{
+			target:          Target{SocketPath: "/tmp/compozy.sock"},
+			baseURL:         "http://daemon",
+			readRetryDelays: []time.Duration{time.Millisecond, time.Millisecond},
+			httpClient: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					*calls++
+					if *calls <= refusals {
+						return nil, &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}
+					}
+					switch req.Method + " " + req.URL.Path {
+					case http.MethodGet + " /api/runs/run-1":
+						return jsonStructResponse(t, http.StatusOK, contract.RunResponse{
+							Run: contract.Run{RunID: "run-1", Status: "running"},
+						}), nil
+					case http.MethodPost + " /api/runs/run-1/cancel":
+						return jsonResponse(http.StatusAccepted, `{"accepted":true}`), nil
+					default:
+						t.Fatalf("unexpected request %s %s", req.Method, req.URL.RequestURI())
+						return nil, nil
+					}
+				}),
+			},
+		}
+	}
+
+	t.Run("Should retry a read until the daemon accepts connections", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		run, err := newClient(&calls, 2).GetRun(context.Background(), "run-1")
+		if err != nil {
+			t.Fatalf("GetRun() error = %v", err)
+		}
+		if run.RunID != "run-1" || calls != 3 {
+			t.Fatalf("GetRun() = %#v after %d calls, want run-1 after 3 calls", run, calls)
+		}
+	})
+
+	t.Run("Should stop retrying a read once the budget is spent", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		if _, err := newClient(&calls, 5).GetRun(context.Background(), "run-1"); !errors.Is(err, syscall.ECONNREFUSED) {
+			t.Fatalf("GetRun() error = %v, want ECONNREFUSED", err)
+		}
+		if calls != 3 {
+			t.Fatalf("GetRun() calls = %d, want 3", calls)
+		}
+	})
+
+	t.Run("Should not replay mutations", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		if err := newClient(&calls, 1).CancelRun(context.Background(), "run-1"); err == nil {
+			t.Fatal("CancelRun() error = nil, want dial error")
+		}
+		if calls != 1 {
+			t.Fatalf("CancelRun() calls = %d, want 1", calls)
+		}
+	})
+}
+
 func jsonStructResponse(t *testing.T, status int, payload any) *http.Response {
 	t.Helper()
 
@@ -40,6 +40,51 @@ func (c *Client) StopDaemon(ctx context.Context, force bool) error {
 	return err
 }
 
+// Maintenance returns the persisted daemon maintenance pauses.
+func (c *Client) Maintenance(ctx context.Context) (apicore.MaintenanceState, error) {
+	if c == nil {
+		return apicore.MaintenanceState{}, ErrDaemonClientRequired
+	}
+
+	var response contract.MaintenanceResponse
+	if _, err := c.doJSON(ctx, http.MethodGet, "/api/daemon/maintenance", nil, &response); err != nil {
+		return apicore.MaintenanceState{}, err
+	}
+	return response.Maintenance, nil
+}
+
+// PauseMaintenance pauses new run starts daemon-wide or for one workflow.
+func (c *Client) PauseMaintenance(
+	ctx context.Context,
+	req apicore.MaintenanceRequest,
+) (apicore.MaintenanceState, error) {
+	return c.updateMaintenance(ctx, "/api/daemon/maintenance/pause", req)
+}
+
+// ResumeMaintenance lifts a daemon-wide or workflow maintenance pause.
+func (c *Client) ResumeMaintenance(
+	ctx context.Context,
+	req apicore.MaintenanceRequest,
+) (apicore.MaintenanceState, error) {
+	return c.updateMaintenance(ctx, "/api/daemon/maintenance/resume", req)
+}
+
+func (c *Client) updateMaintenance(
+	ctx context.Context,
+	path string,
+	req apicore.MaintenanceRequest,
+) (apicore.MaintenanceState, error) {
+	if c == nil {
+		return apicore.MaintenanceState{}, ErrDaemonClientRequired
+	}
+
+	var response contract.MaintenanceResponse
+	if _, err := c.doJSON(ctx, http.MethodPost, path, req, &response); err != nil {
+		return apicore.MaintenanceState{}, err
+	}
+	return response.Maintenance, nil
+}
+
 // RegisterWorkspace registers one workspace explicitly.
 func (c *Client) RegisterWorkspace(
 	ctx context.Context,
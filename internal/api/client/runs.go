@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +29,7 @@ type RunListOptions struct {
 	Status    string
 	Statuses  []string
 	Mode      string
+	Labels    map[string]string
 	Limit     int
 }
 
@@ -114,6 +117,9 @@ func (c *Client) ListRuns(ctx context.Context, opts RunListOptions) ([]apicore.R
 	if mode := strings.TrimSpace(opts.Mode); mode != "" {
 		values.Set("mode", mode)
 	}
+	for _, key := range slices.Sorted(maps.Keys(opts.Labels)) {
+		values.Add("label", key+"="+opts.Labels[key])
+	}
 	if opts.Limit > 0 {
 		values.Set("limit", fmt.Sprintf("%d", opts.Limit))
 	}
@@ -240,6 +246,28 @@ func (c *Client) SendRunJobMessage(
 	return response, nil
 }
 
+// CompareRuns diffs two runs of the same workflow job by job.
+func (c *Client) CompareRuns(ctx context.Context, runA string, runB string) (apicore.RunComparison, error) {
+	if c == nil {
+		return apicore.RunComparison{}, ErrDaemonClientRequired
+	}
+
+	trimmedA := strings.TrimSpace(runA)
+	trimmedB := strings.TrimSpace(runB)
+	if trimmedA == "" || trimmedB == "" {
+		return apicore.RunComparison{}, ErrRunIDRequired
+	}
+
+	values := url.Values{}
+	values.Set("a", trimmedA)
+	values.Set("b", trimmedB)
+	var response contract.RunComparisonResponse
+	if _, err := c.doJSON(ctx, http.MethodGet, "/api/runs/compare?"+values.Encode(), nil, &response); err != nil {
+		return apicore.RunComparison{}, err
+	}
+	return response.Comparison, nil
+}
+
 // GetRunSnapshot loads the dense attach snapshot for one run.
 func (c *Client) GetRunSnapshot(ctx context.Context, runID string) (apicore.RunSnapshot, error) {
 	if c == nil {
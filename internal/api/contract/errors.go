@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type ErrorCode string
@@ -30,6 +31,10 @@ const (
 	CodeWorkspacePathNeeded   ErrorCode = "workspace_path_required"
 	CodePromptRequired        ErrorCode = "prompt_required"
 	CodeStreamUnavailable     ErrorCode = "stream_unavailable"
+	CodeMaintenanceMode       ErrorCode = "maintenance_mode"
+	CodeLabelsInvalid         ErrorCode = "labels_invalid"
+	CodeWaitInvalid           ErrorCode = "wait_invalid"
+	CodeRunCompareMismatch    ErrorCode = "run_compare_mismatch"
 )
 
 var CanonicalErrorCodes = []ErrorCode{
@@ -54,6 +59,10 @@ var CanonicalErrorCodes = []ErrorCode{
 	CodeWorkspacePathNeeded,
 	CodePromptRequired,
 	CodeStreamUnavailable,
+	CodeMaintenanceMode,
+	CodeLabelsInvalid,
+	CodeWaitInvalid,
+	CodeRunCompareMismatch,
 }
 
 type TransportError struct {
@@ -71,6 +80,9 @@ type Problem struct {
 	Message string
 	Details map[string]any
 	Err     error
+	// RetryAfter, when positive, is sent as the Retry-After header so
+	// clients know when a rejected request is worth retrying.
+	RetryAfter time.Duration
 }
 
 func NewProblem(status int, code string, message string, details map[string]any, err error) *Problem {
@@ -33,6 +33,24 @@ var RouteInventory = []RouteSpec{
 		ResponseType: "MutationAcceptedResponse",
 		TimeoutClass: TimeoutMutate,
 	},
+	{
+		Method:       http.MethodGet,
+		Path:         "/api/daemon/maintenance",
+		ResponseType: "MaintenanceResponse",
+		TimeoutClass: TimeoutRead,
+	},
+	{
+		Method:       http.MethodPost,
+		Path:         "/api/daemon/maintenance/pause",
+		ResponseType: "MaintenanceResponse",
+		TimeoutClass: TimeoutMutate,
+	},
+	{
+		Method:       http.MethodPost,
+		Path:         "/api/daemon/maintenance/resume",
+		ResponseType: "MaintenanceResponse",
+		TimeoutClass: TimeoutMutate,
+	},
 	{Method: http.MethodPost, Path: "/api/workspaces", ResponseType: "WorkspaceResponse", TimeoutClass: TimeoutMutate},
 	{Method: http.MethodGet, Path: "/api/workspaces", ResponseType: "WorkspaceListResponse", TimeoutClass: TimeoutRead},
 	{
@@ -178,6 +196,12 @@ var RouteInventory = []RouteSpec{
 		TimeoutClass: TimeoutLongMutate,
 	},
 	{Method: http.MethodGet, Path: "/api/runs", ResponseType: "RunListResponse", TimeoutClass: TimeoutRead},
+	{
+		Method:       http.MethodGet,
+		Path:         "/api/runs/compare",
+		ResponseType: "RunComparisonResponse",
+		TimeoutClass: TimeoutRead,
+	},
 	{Method: http.MethodGet, Path: "/api/runs/:run_id", ResponseType: "RunResponse", TimeoutClass: TimeoutRead},
 	{
 		Method:       http.MethodGet,
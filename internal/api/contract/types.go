@@ -51,6 +51,7 @@ type TaskRunRequest struct {
 	PresentationMode string                   `json:"presentation_mode,omitempty"`
 	RuntimeOverrides json.RawMessage          `json:"runtime_overrides,omitempty"`
 	Execution        *TaskExecutionDescriptor `json:"execution,omitempty"`
+	Labels           map[string]string        `json:"labels,omitempty"`
 }
 
 type TaskExecutionDescriptor struct {
@@ -68,6 +69,7 @@ type TaskRunMultipleRequest struct {
 	PresentationMode string                   `json:"presentation_mode,omitempty"`
 	RuntimeOverrides json.RawMessage          `json:"runtime_overrides,omitempty"`
 	Execution        *TaskExecutionDescriptor `json:"execution,omitempty"`
+	Labels           map[string]string        `json:"labels,omitempty"`
 }
 
 type TaskRunMultipleItem struct {
@@ -101,27 +103,29 @@ type ReviewFetchRequest struct {
 }
 
 type ReviewRunRequest struct {
-	Workspace        string          `json:"workspace"`
-	PresentationMode string          `json:"presentation_mode,omitempty"`
-	RuntimeOverrides json.RawMessage `json:"runtime_overrides,omitempty"`
-	Batching         json.RawMessage `json:"batching,omitempty"`
+	Workspace        string            `json:"workspace"`
+	PresentationMode string            `json:"presentation_mode,omitempty"`
+	RuntimeOverrides json.RawMessage   `json:"runtime_overrides,omitempty"`
+	Batching         json.RawMessage   `json:"batching,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
 }
 
 type ReviewWatchRequest struct {
-	Workspace        string          `json:"workspace"`
-	PresentationMode string          `json:"presentation_mode,omitempty"`
-	Provider         string          `json:"provider,omitempty"`
-	PRRef            string          `json:"pr_ref"`
-	UntilClean       bool            `json:"until_clean,omitempty"`
-	MaxRounds        int             `json:"max_rounds,omitempty"`
-	AutoPush         bool            `json:"auto_push,omitempty"`
-	PushRemote       string          `json:"push_remote,omitempty"`
-	PushBranch       string          `json:"push_branch,omitempty"`
-	PollInterval     string          `json:"poll_interval,omitempty"`
-	ReviewTimeout    string          `json:"review_timeout,omitempty"`
-	QuietPeriod      string          `json:"quiet_period,omitempty"`
-	RuntimeOverrides json.RawMessage `json:"runtime_overrides,omitempty"`
-	Batching         json.RawMessage `json:"batching,omitempty"`
+	Workspace        string            `json:"workspace"`
+	PresentationMode string            `json:"presentation_mode,omitempty"`
+	Provider         string            `json:"provider,omitempty"`
+	PRRef            string            `json:"pr_ref"`
+	UntilClean       bool              `json:"until_clean,omitempty"`
+	MaxRounds        int               `json:"max_rounds,omitempty"`
+	AutoPush         bool              `json:"auto_push,omitempty"`
+	PushRemote       string            `json:"push_remote,omitempty"`
+	PushBranch       string            `json:"push_branch,omitempty"`
+	PollInterval     string            `json:"poll_interval,omitempty"`
+	ReviewTimeout    string            `json:"review_timeout,omitempty"`
+	QuietPeriod      string            `json:"quiet_period,omitempty"`
+	RuntimeOverrides json.RawMessage   `json:"runtime_overrides,omitempty"`
+	Batching         json.RawMessage   `json:"batching,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
 }
 
 type SyncRequest struct {
@@ -131,10 +135,17 @@ type SyncRequest struct {
 }
 
 type ExecRequest struct {
-	WorkspacePath    string          `json:"workspace_path"`
-	Prompt           string          `json:"prompt"`
-	PresentationMode string          `json:"presentation_mode,omitempty"`
-	RuntimeOverrides json.RawMessage `json:"runtime_overrides,omitempty"`
+	WorkspacePath    string            `json:"workspace_path"`
+	Prompt           string            `json:"prompt"`
+	PresentationMode string            `json:"presentation_mode,omitempty"`
+	RuntimeOverrides json.RawMessage   `json:"runtime_overrides,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+}
+
+type MaintenanceRequest struct {
+	Workspace string `json:"workspace,omitempty"`
+	Workflow  string `json:"workflow,omitempty"`
+	Reason    string `json:"reason,omitempty"`
 }
 
 type RunJobMessageRequest struct {
@@ -176,6 +187,20 @@ type DaemonHealth struct {
 	Details             []HealthDetail             `json:"details,omitempty"`
 }
 
+type MaintenanceState struct {
+	Paused    bool                  `json:"paused"`
+	Reason    string                `json:"reason,omitempty"`
+	PausedAt  *time.Time            `json:"paused_at,omitempty"`
+	Workflows []MaintenanceWorkflow `json:"workflows"`
+}
+
+type MaintenanceWorkflow struct {
+	WorkspaceID  string    `json:"workspace_id"`
+	WorkflowSlug string    `json:"workflow_slug"`
+	Reason       string    `json:"reason,omitempty"`
+	PausedAt     time.Time `json:"paused_at"`
+}
+
 type HealthDetail struct {
 	Code     string `json:"code"`
 	Message  string `json:"message"`
@@ -398,18 +423,19 @@ type SessionViewSnapshot struct {
 }
 
 type Run struct {
-	RunID            string     `json:"run_id"`
-	WorkspaceID      string     `json:"workspace_id"`
-	WorkflowID       *string    `json:"workflow_id,omitempty"`
-	WorkflowSlug     string     `json:"workflow_slug,omitempty"`
-	ParentRunID      string     `json:"parent_run_id,omitempty"`
-	Mode             string     `json:"mode"`
-	Status           string     `json:"status"`
-	PresentationMode string     `json:"presentation_mode"`
-	StartedAt        time.Time  `json:"started_at"`
-	EndedAt          *time.Time `json:"ended_at,omitempty"`
-	ErrorText        string     `json:"error_text,omitempty"`
-	RequestID        string     `json:"request_id,omitempty"`
+	RunID            string            `json:"run_id"`
+	WorkspaceID      string            `json:"workspace_id"`
+	WorkflowID       *string           `json:"workflow_id,omitempty"`
+	WorkflowSlug     string            `json:"workflow_slug,omitempty"`
+	ParentRunID      string            `json:"parent_run_id,omitempty"`
+	Mode             string            `json:"mode"`
+	Status           string            `json:"status"`
+	PresentationMode string            `json:"presentation_mode"`
+	StartedAt        time.Time         `json:"started_at"`
+	EndedAt          *time.Time        `json:"ended_at,omitempty"`
+	ErrorText        string            `json:"error_text,omitempty"`
+	RequestID        string            `json:"request_id,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
 }
 
 type RunJobSummary struct {
@@ -541,11 +567,43 @@ type RunSnapshot struct {
 	NextCursor        *StreamCursor          `json:"-"`
 }
 
+type RunComparison struct {
+	A    RunComparisonSide  `json:"a"`
+	B    RunComparisonSide  `json:"b"`
+	Jobs []RunJobComparison `json:"jobs"`
+}
+
+type RunComparisonSide struct {
+	Run        Run         `json:"run"`
+	DurationMs int64       `json:"duration_ms,omitempty"`
+	Usage      kinds.Usage `json:"usage,omitempty"`
+}
+
+type RunJobComparison struct {
+	Key              string                `json:"key"`
+	Title            string                `json:"title,omitempty"`
+	A                *RunJobComparisonSide `json:"a,omitempty"`
+	B                *RunJobComparisonSide `json:"b,omitempty"`
+	StatusChanged    bool                  `json:"status_changed,omitempty"`
+	DurationDeltaMs  int64                 `json:"duration_delta_ms,omitempty"`
+	TotalTokensDelta int                   `json:"total_tokens_delta,omitempty"`
+}
+
+type RunJobComparisonSide struct {
+	JobID      string      `json:"job_id"`
+	Status     string      `json:"status"`
+	Model      string      `json:"model,omitempty"`
+	Attempt    int         `json:"attempt,omitempty"`
+	DurationMs int64       `json:"duration_ms,omitempty"`
+	Usage      kinds.Usage `json:"usage,omitempty"`
+}
+
 type RunListQuery struct {
 	Workspace string
 	Status    string
 	Statuses  []string
 	Mode      string
+	Labels    map[string]string
 	Limit     int
 }
 
@@ -586,6 +644,10 @@ type DaemonHealthResponse struct {
 	Health DaemonHealth `json:"health"`
 }
 
+type MaintenanceResponse struct {
+	Maintenance MaintenanceState `json:"maintenance"`
+}
+
 type WorkspaceResponse struct {
 	Workspace Workspace `json:"workspace"`
 }
@@ -634,6 +696,10 @@ type RunResponse struct {
 	Run Run `json:"run"`
 }
 
+type RunComparisonResponse struct {
+	Comparison RunComparison `json:"comparison"`
+}
+
 type RunSnapshotResponse struct {
 	Run               Run                    `json:"run"`
 	Jobs              []RunJobState          `json:"jobs,omitempty"`
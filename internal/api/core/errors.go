@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/compozy/compozy/internal/core/reviews"
@@ -146,6 +148,10 @@ func RespondError(c *gin.Context, err error) {
 	}
 
 	status := statusForError(err)
+	var problem *Problem
+	if errors.As(err, &problem) && problem != nil && problem.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(problem.RetryAfter.Seconds())), 10))
+	}
 	c.AbortWithStatusJSON(
 		status,
 		contract.TransportErrorEnvelope(
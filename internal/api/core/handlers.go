@@ -22,6 +22,9 @@ import (
 
 const maxPageLimit = 500
 
+// maxRunWait caps how long GET /api/runs/:run_id?wait= may hold a request.
+const maxRunWait = 5 * time.Minute
+
 const workspaceSocketWriteTimeout = 5 * time.Second
 
 var workspaceSocketOriginPatterns = []string{
@@ -45,6 +48,7 @@ type Handlers struct {
 	Runs            RunService
 	Sync            SyncService
 	Exec            ExecService
+	Maintenance     MaintenanceService
 
 	settingsMu                    sync.RWMutex
 	streamDone                    <-chan struct{}
@@ -94,6 +98,7 @@ func NewHandlers(cfg *HandlerConfig) *Handlers {
 		Runs:                          cfg.Runs,
 		Sync:                          cfg.Sync,
 		Exec:                          cfg.Exec,
+		Maintenance:                   cfg.Maintenance,
 		streamDone:                    done,
 		workspaceSocketOriginPatterns: originPatterns,
 		httpPort:                      &atomic.Int64{},
@@ -121,6 +126,7 @@ func (h *Handlers) Clone() *Handlers {
 		Runs:                          h.Runs,
 		Sync:                          h.Sync,
 		Exec:                          h.Exec,
+		Maintenance:                   h.Maintenance,
 	})
 	clone.httpPort = h.httpPort
 	return clone
@@ -316,6 +322,22 @@ func parsePositiveInt(value string, field string) (int, error) {
 	return parsed, nil
 }
 
+func parseRunWait(value string) (time.Duration, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, nil
+	}
+	wait, err := time.ParseDuration(trimmed)
+	if err != nil || wait <= 0 || wait > maxRunWait {
+		return 0, validationProblem(
+			"wait_invalid",
+			fmt.Sprintf("wait must be a positive duration no longer than %s", maxRunWait),
+			map[string]any{"field": "wait"},
+		)
+	}
+	return wait, nil
+}
+
 func parseOptionalBool(value string, field string) (bool, error) {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
@@ -463,6 +485,61 @@ func (h *Handlers) StopDaemon(c *gin.Context) {
 	c.JSON(http.StatusAccepted, contract.MutationAcceptedResponse{Accepted: true})
 }
 
+// GetMaintenance returns the persisted maintenance pauses.
+func (h *Handlers) GetMaintenance(c *gin.Context) {
+	if h.Maintenance == nil {
+		h.respondError(c, serviceUnavailableProblem("maintenance service"))
+		return
+	}
+
+	state, err := h.Maintenance.State(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, contract.MaintenanceResponse{Maintenance: state})
+}
+
+// PauseMaintenance stops new run starts daemon-wide or for one workflow.
+func (h *Handlers) PauseMaintenance(c *gin.Context) {
+	h.updateMaintenance(c, "decode maintenance pause request", MaintenanceService.Pause)
+}
+
+// ResumeMaintenance lifts a daemon-wide or workflow maintenance pause.
+func (h *Handlers) ResumeMaintenance(c *gin.Context) {
+	h.updateMaintenance(c, "decode maintenance resume request", MaintenanceService.Resume)
+}
+
+func (h *Handlers) updateMaintenance(
+	c *gin.Context,
+	action string,
+	apply func(MaintenanceService, context.Context, MaintenanceRequest) (MaintenanceState, error),
+) {
+	if h.Maintenance == nil {
+		h.respondError(c, serviceUnavailableProblem("maintenance service"))
+		return
+	}
+
+	var body MaintenanceRequest
+	if c.Request.ContentLength != 0 && !h.bindJSON(c, action, &body) {
+		return
+	}
+	body.Workspace = strings.TrimSpace(body.Workspace)
+	body.Workflow = strings.TrimSpace(body.Workflow)
+	body.Reason = strings.TrimSpace(body.Reason)
+	if body.Workflow != "" && body.Workspace == "" {
+		h.respondError(c, validationProblem("workspace_required", "workspace is required to pause a workflow", nil))
+		return
+	}
+
+	state, err := apply(h.Maintenance, c.Request.Context(), body)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, contract.MaintenanceResponse{Maintenance: state})
+}
+
 // RegisterWorkspace registers a workspace explicitly.
 func (h *Handlers) RegisterWorkspace(c *gin.Context) {
 	if h.Workspaces == nil {
@@ -833,11 +910,17 @@ func (h *Handlers) StartTaskRun(c *gin.Context) {
 		return
 	}
 
-	run, err := h.Tasks.StartRun(c.Request.Context(), workspace, c.Param("slug"), TaskRunRequest{
+	ctx, labels, ok := h.runStartContext(c, body.Labels)
+	if !ok {
+		return
+	}
+
+	run, err := h.Tasks.StartRun(ctx, workspace, c.Param("slug"), TaskRunRequest{
 		Workspace:        workspace,
 		PresentationMode: strings.TrimSpace(body.PresentationMode),
 		RuntimeOverrides: body.RuntimeOverrides,
 		Execution:        body.Execution,
+		Labels:           labels,
 	})
 	if err != nil {
 		h.respondWorkspaceContextError(c, workspace, err)
@@ -867,7 +950,12 @@ func (h *Handlers) StartTaskRunMultiple(c *gin.Context) {
 		return
 	}
 
-	run, err := h.Tasks.StartRunMultiple(c.Request.Context(), workspace, TaskRunMultipleRequest{
+	ctx, labels, ok := h.runStartContext(c, body.Labels)
+	if !ok {
+		return
+	}
+
+	run, err := h.Tasks.StartRunMultiple(ctx, workspace, TaskRunMultipleRequest{
 		Workspace:        workspace,
 		Slugs:            slugs,
 		Mode:             strings.TrimSpace(body.Mode),
@@ -875,6 +963,7 @@ func (h *Handlers) StartTaskRunMultiple(c *gin.Context) {
 		PresentationMode: strings.TrimSpace(body.PresentationMode),
 		RuntimeOverrides: body.RuntimeOverrides,
 		Execution:        body.Execution,
+		Labels:           labels,
 	})
 	if err != nil {
 		h.respondWorkspaceContextError(c, workspace, err)
@@ -1095,11 +1184,17 @@ func (h *Handlers) StartReviewRun(c *gin.Context) {
 		return
 	}
 
-	run, err := h.Reviews.StartRun(c.Request.Context(), workspace, c.Param("slug"), round, ReviewRunRequest{
+	ctx, labels, ok := h.runStartContext(c, body.Labels)
+	if !ok {
+		return
+	}
+
+	run, err := h.Reviews.StartRun(ctx, workspace, c.Param("slug"), round, ReviewRunRequest{
 		Workspace:        workspace,
 		PresentationMode: strings.TrimSpace(body.PresentationMode),
 		RuntimeOverrides: body.RuntimeOverrides,
 		Batching:         body.Batching,
+		Labels:           labels,
 	})
 	if err != nil {
 		h.respondWorkspaceContextError(c, workspace, err)
@@ -1130,7 +1225,12 @@ func (h *Handlers) StartReviewWatch(c *gin.Context) {
 		return
 	}
 
-	run, err := h.Reviews.StartWatch(c.Request.Context(), workspace, c.Param("slug"), ReviewWatchRequest{
+	ctx, labels, ok := h.runStartContext(c, body.Labels)
+	if !ok {
+		return
+	}
+
+	run, err := h.Reviews.StartWatch(ctx, workspace, c.Param("slug"), ReviewWatchRequest{
 		Workspace:        workspace,
 		PresentationMode: strings.TrimSpace(body.PresentationMode),
 		Provider:         strings.TrimSpace(body.Provider),
@@ -1145,6 +1245,7 @@ func (h *Handlers) StartReviewWatch(c *gin.Context) {
 		QuietPeriod:      strings.TrimSpace(body.QuietPeriod),
 		RuntimeOverrides: body.RuntimeOverrides,
 		Batching:         body.Batching,
+		Labels:           labels,
 	})
 	if err != nil {
 		h.respondWorkspaceContextError(c, workspace, err)
@@ -1177,11 +1278,18 @@ func (h *Handlers) ListRuns(c *gin.Context) {
 		return
 	}
 
+	labels, err := ParseRunLabels(c.QueryArray("label")...)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
 	runs, err := h.Runs.List(c.Request.Context(), RunListQuery{
 		Workspace: h.optionalWorkspaceContext(c, c.Query("workspace")),
 		Status:    strings.TrimSpace(c.Query("status")),
 		Statuses:  runListStatusFilters(c.QueryArray("status")),
 		Mode:      strings.TrimSpace(c.Query("mode")),
+		Labels:    labels,
 		Limit:     limit,
 	})
 	if err != nil {
@@ -1210,14 +1318,29 @@ func runListStatusFilters(values []string) []string {
 	return filters
 }
 
-// GetRun returns one run summary.
+// GetRun returns one run summary. With ?wait=<duration> it holds the request
+// until the run reaches a terminal status or the wait elapses, then returns
+// the latest summary either way.
 func (h *Handlers) GetRun(c *gin.Context) {
 	if h.Runs == nil {
 		h.respondError(c, serviceUnavailableProblem("run service"))
 		return
 	}
 
-	run, err := h.Runs.Get(c.Request.Context(), c.Param("run_id"))
+	wait, err := parseRunWait(c.Query("wait"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var run Run
+	if wait > 0 {
+		waitCtx, cancel := context.WithTimeout(c.Request.Context(), wait)
+		run, err = h.Runs.Wait(waitCtx, c.Param("run_id"))
+		cancel()
+	} else {
+		run, err = h.Runs.Get(c.Request.Context(), c.Param("run_id"))
+	}
 	if err != nil {
 		h.respondError(c, err)
 		return
@@ -1624,11 +1747,17 @@ func (h *Handlers) StartExecRun(c *gin.Context) {
 		return
 	}
 
-	run, err := h.Exec.Start(c.Request.Context(), ExecRequest{
+	ctx, labels, ok := h.runStartContext(c, body.Labels)
+	if !ok {
+		return
+	}
+
+	run, err := h.Exec.Start(ctx, ExecRequest{
 		WorkspacePath:    strings.TrimSpace(body.WorkspacePath),
 		Prompt:           strings.TrimSpace(body.Prompt),
 		PresentationMode: strings.TrimSpace(body.PresentationMode),
 		RuntimeOverrides: body.RuntimeOverrides,
+		Labels:           labels,
 	})
 	if err != nil {
 		h.respondError(c, err)
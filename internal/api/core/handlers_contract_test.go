@@ -218,7 +218,7 @@ func TestRunStartEndpointsReturnCanonicalRunEnvelopes(t *testing.T) {
 
 			var payload contract.RunResponse
 			decodeJSON(t, response.Body.Bytes(), &payload)
-			if payload.Run != tc.wantRun {
+			if !reflect.DeepEqual(payload.Run, tc.wantRun) {
 				t.Fatalf("payload.Run = %#v, want %#v", payload.Run, tc.wantRun)
 			}
 		})
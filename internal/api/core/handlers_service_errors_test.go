@@ -796,6 +796,10 @@ func (s *errorRunService) Get(context.Context, string) (core.Run, error) {
 	return core.Run{}, s.err
 }
 
+func (s *errorRunService) Wait(context.Context, string) (core.Run, error) {
+	return core.Run{}, s.err
+}
+
 func (s *errorRunService) Snapshot(context.Context, string) (core.RunSnapshot, error) {
 	return core.RunSnapshot{}, s.err
 }
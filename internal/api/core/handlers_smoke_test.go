@@ -661,6 +661,10 @@ func (s *smokeRunService) Get(context.Context, string) (core.Run, error) {
 	return s.run, nil
 }
 
+func (s *smokeRunService) Wait(context.Context, string) (core.Run, error) {
+	return s.run, nil
+}
+
 func (s *smokeRunService) Snapshot(context.Context, string) (core.RunSnapshot, error) {
 	return s.snapshot, nil
 }
@@ -20,8 +20,10 @@ import (
 	"github.com/coder/websocket/wsjson"
 	"github.com/gin-gonic/gin"
 
+	"github.com/compozy/compozy/internal/api/contract"
 	"github.com/compozy/compozy/internal/api/core"
 	"github.com/compozy/compozy/internal/store/globaldb"
+	"github.com/compozy/compozy/pkg/compozy/events/kinds"
 )
 
 func TestNon2xxResponsesIncludeRequestIDAndEnvelope(t *testing.T) {
@@ -426,6 +428,183 @@ func TestListRunsParsesMultiStatusFilters(t *testing.T) {
 	}
 }
 
+func TestGetRunWaitHoldsUntilServiceReturns(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	newEngine := func(runs *fakeRunService) *gin.Engine {
+		handlers := core.NewHandlers(&core.HandlerConfig{TransportName: "test", Runs: runs})
+		engine := gin.New()
+		engine.Use(core.RequestIDMiddleware())
+		engine.Use(core.ErrorMiddleware())
+		core.RegisterRoutes(engine, handlers)
+		return engine
+	}
+	serve := func(engine *gin.Engine, target string) *httptest.ResponseRecorder {
+		request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, target, http.NoBody)
+		response := httptest.NewRecorder()
+		engine.ServeHTTP(response, request)
+		return response
+	}
+
+	t.Run("Should pass a bounded context to the run service", func(t *testing.T) {
+		t.Parallel()
+
+		var gotRunID string
+		var gotDeadline time.Duration
+		engine := newEngine(&fakeRunService{
+			wait: func(ctx context.Context, runID string) (core.Run, error) {
+				gotRunID = runID
+				deadline, ok := ctx.Deadline()
+				if !ok {
+					t.Fatal("expected wait context to carry a deadline")
+				}
+				gotDeadline = time.Until(deadline)
+				return core.Run{RunID: runID, Status: "completed"}, nil
+			},
+		})
+
+		response := serve(engine, "/api/runs/run-1?wait=30s")
+		if response.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body=%s", response.Code, http.StatusOK, response.Body.String())
+		}
+		if gotRunID != "run-1" {
+			t.Fatalf("wait run id = %q, want run-1", gotRunID)
+		}
+		if gotDeadline <= 0 || gotDeadline > 30*time.Second {
+			t.Fatalf("wait deadline = %s, want within 30s", gotDeadline)
+		}
+		if !strings.Contains(response.Body.String(), `"status":"completed"`) {
+			t.Fatalf("body = %s, want completed run", response.Body.String())
+		}
+	})
+
+	t.Run("Should reject invalid wait durations", func(t *testing.T) {
+		t.Parallel()
+
+		engine := newEngine(&fakeRunService{
+			wait: func(context.Context, string) (core.Run, error) {
+				t.Fatal("wait must not be called for an invalid duration")
+				return core.Run{}, nil
+			},
+		})
+		for _, value := range []string{"soon", "0s", "-1s", "10m"} {
+			response := serve(engine, "/api/runs/run-1?wait="+value)
+			if response.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("wait=%s status = %d, want %d", value, response.Code, http.StatusUnprocessableEntity)
+			}
+			if !strings.Contains(response.Body.String(), `"code":"wait_invalid"`) {
+				t.Fatalf("wait=%s body = %s, want wait_invalid", value, response.Body.String())
+			}
+		}
+	})
+}
+
+func TestCompareRunsDiffsJobsAcrossRuns(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	startedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	endedA := startedAt.Add(90 * time.Second)
+	endedB := startedAt.Add(60 * time.Second)
+	snapshots := map[string]core.RunSnapshot{
+		"run-a": {
+			Run: core.Run{RunID: "run-a", WorkspaceID: "ws-1", WorkflowSlug: "auth", Mode: "task",
+				StartedAt: startedAt, EndedAt: &endedA},
+			Jobs: []core.RunJobState{
+				{JobID: "job-001", TaskID: "task_01", Status: "completed", Summary: &core.RunJobSummary{
+					TaskTitle: "Add login", DurationMs: 4000, Usage: kinds.Usage{TotalTokens: 1000},
+				}},
+				{JobID: "job-002", TaskID: "task_02", Status: "failed"},
+			},
+		},
+		"run-b": {
+			Run: core.Run{RunID: "run-b", WorkspaceID: "ws-1", WorkflowSlug: "auth", Mode: "task",
+				StartedAt: startedAt, EndedAt: &endedB},
+			Jobs: []core.RunJobState{
+				{JobID: "job-007", TaskID: "task_01", Status: "completed", Summary: &core.RunJobSummary{
+					TaskTitle: "Add login", DurationMs: 2500, Usage: kinds.Usage{TotalTokens: 1400},
+				}},
+				{JobID: "job-008", TaskID: "task_02", Status: "completed"},
+				{JobID: "job-009", TaskID: "task_03", Status: "completed"},
+			},
+		},
+		"run-other": {Run: core.Run{RunID: "run-other", WorkspaceID: "ws-1", WorkflowSlug: "billing", Mode: "task"}},
+	}
+	handlers := core.NewHandlers(&core.HandlerConfig{
+		TransportName: "test",
+		Runs: &fakeRunService{
+			snapshot: func(_ context.Context, runID string) (core.RunSnapshot, error) {
+				return snapshots[runID], nil
+			},
+		},
+	})
+	engine := gin.New()
+	engine.Use(core.RequestIDMiddleware())
+	engine.Use(core.ErrorMiddleware())
+	core.RegisterRoutes(engine, handlers)
+	serve := func(target string) *httptest.ResponseRecorder {
+		request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, target, http.NoBody)
+		response := httptest.NewRecorder()
+		engine.ServeHTTP(response, request)
+		return response
+	}
+
+	t.Run("Should pair jobs by task and report deltas", func(t *testing.T) {
+		t.Parallel()
+
+		response := serve("/api/runs/compare?a=run-a&b=run-b")
+		if response.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body=%s", response.Code, http.StatusOK, response.Body.String())
+		}
+		var payload contract.RunComparisonResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &payload); err != nil {
+			t.Fatalf("decode comparison: %v", err)
+		}
+		got := payload.Comparison
+		if got.A.DurationMs != 90000 || got.B.DurationMs != 60000 {
+			t.Fatalf("run durations = %d/%d, want 90000/60000", got.A.DurationMs, got.B.DurationMs)
+		}
+		if len(got.Jobs) != 3 {
+			t.Fatalf("jobs = %#v, want three task entries", got.Jobs)
+		}
+		first := got.Jobs[0]
+		if first.Key != "task_01" || first.Title != "Add login" || first.StatusChanged ||
+			first.DurationDeltaMs != -1500 || first.TotalTokensDelta != 400 {
+			t.Fatalf("task_01 comparison = %#v", first)
+		}
+		if second := got.Jobs[1]; second.Key != "task_02" || !second.StatusChanged {
+			t.Fatalf("task_02 comparison = %#v, want status change", second)
+		}
+		if third := got.Jobs[2]; third.Key != "task_03" || third.A != nil || third.B == nil {
+			t.Fatalf("task_03 comparison = %#v, want b-only entry", third)
+		}
+	})
+
+	t.Run("Should reject runs of different workflows", func(t *testing.T) {
+		t.Parallel()
+
+		response := serve("/api/runs/compare?a=run-a&b=run-other")
+		if response.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("status = %d, want %d", response.Code, http.StatusUnprocessableEntity)
+		}
+		if !strings.Contains(response.Body.String(), `"code":"run_compare_mismatch"`) {
+			t.Fatalf("body = %s, want run_compare_mismatch", response.Body.String())
+		}
+	})
+
+	t.Run("Should require both run ids", func(t *testing.T) {
+		t.Parallel()
+
+		response := serve("/api/runs/compare?a=run-a")
+		if response.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("status = %d, want %d", response.Code, http.StatusUnprocessableEntity)
+		}
+	})
+}
+
 func TestRunJobControlHandlersForwardPauseAndMessageRequests(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +715,8 @@ func TestRunJobControlHandlersForwardPauseAndMessageRequests(t *testing.T) {
 type fakeRunService struct {
 	getErr         error
 	list           func(context.Context, core.RunListQuery) ([]core.Run, error)
+	wait           func(context.Context, string) (core.Run, error)
+	snapshot       func(context.Context, string) (core.RunSnapshot, error)
 	openStream     func(context.Context, string, core.StreamCursor) (core.RunStream, error)
 	pauseRunJob    func(context.Context, string, string) (core.RunJobControlResponse, error)
 	sendRunMessage func(context.Context, string, string, core.RunJobMessageRequest) (core.RunJobControlResponse, error)
@@ -552,7 +733,17 @@ func (f *fakeRunService) Get(context.Context, string) (core.Run, error) {
 	return core.Run{}, f.getErr
 }
 
-func (f *fakeRunService) Snapshot(context.Context, string) (core.RunSnapshot, error) {
+func (f *fakeRunService) Wait(ctx context.Context, runID string) (core.Run, error) {
+	if f.wait != nil {
+		return f.wait(ctx, runID)
+	}
+	return core.Run{}, f.getErr
+}
+
+func (f *fakeRunService) Snapshot(ctx context.Context, runID string) (core.RunSnapshot, error) {
+	if f.snapshot != nil {
+		return f.snapshot(ctx, runID)
+	}
 	return core.RunSnapshot{}, nil
 }
 
@@ -676,3 +867,122 @@ func decodeJSON(t *testing.T, data []byte, dst any) {
 		t.Fatalf("json.Unmarshal() error = %v", err)
 	}
 }
+
+func TestMaintenanceHandlersForwardPauseAndResumeRequests(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	service := &fakeMaintenanceService{}
+	handlers := core.NewHandlers(&core.HandlerConfig{
+		TransportName: "test",
+		Maintenance:   service,
+	})
+	engine := gin.New()
+	engine.Use(core.RequestIDMiddleware())
+	engine.Use(core.ErrorMiddleware())
+	core.RegisterRoutes(engine, handlers)
+
+	serve := func(t *testing.T, method string, target string, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if body == "" {
+			req = httptest.NewRequestWithContext(context.Background(), method, target, http.NoBody)
+		} else {
+			req = httptest.NewRequestWithContext(context.Background(), method, target, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp := httptest.NewRecorder()
+		engine.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Should pause daemon-wide with an empty body", func(t *testing.T) {
+		resp := serve(t, http.MethodPost, "/api/daemon/maintenance/pause", "")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("pause status = %d, want %d; body=%s", resp.Code, http.StatusOK, resp.Body.String())
+		}
+		var payload struct {
+			Maintenance core.MaintenanceState `json:"maintenance"`
+		}
+		decodeJSON(t, resp.Body.Bytes(), &payload)
+		if !payload.Maintenance.Paused {
+			t.Fatalf("pause payload = %#v, want paused", payload.Maintenance)
+		}
+	})
+
+	t.Run("Should forward workflow resume requests", func(t *testing.T) {
+		resp := serve(
+			t,
+			http.MethodPost,
+			"/api/daemon/maintenance/resume",
+			`{"workspace":" ws-1 ","workflow":"billing"}`,
+		)
+		if resp.Code != http.StatusOK {
+			t.Fatalf("resume status = %d, want %d; body=%s", resp.Code, http.StatusOK, resp.Body.String())
+		}
+		if got := service.lastRequest(); got.Workspace != "ws-1" || got.Workflow != "billing" {
+			t.Fatalf("resume request = %#v, want ws-1/billing", got)
+		}
+	})
+
+	t.Run("Should require a workspace for workflow pauses", func(t *testing.T) {
+		resp := serve(t, http.MethodPost, "/api/daemon/maintenance/pause", `{"workflow":"billing"}`)
+		if resp.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("pause status = %d, want %d; body=%s", resp.Code, http.StatusUnprocessableEntity, resp.Body.String())
+		}
+	})
+
+	t.Run("Should report service unavailable without a maintenance service", func(t *testing.T) {
+		bare := gin.New()
+		bare.Use(core.RequestIDMiddleware())
+		bare.Use(core.ErrorMiddleware())
+		core.RegisterRoutes(bare, core.NewHandlers(&core.HandlerConfig{TransportName: "test"}))
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/daemon/maintenance", http.NoBody)
+		resp := httptest.NewRecorder()
+		bare.ServeHTTP(resp, req)
+		if resp.Code != http.StatusServiceUnavailable {
+			t.Fatalf("state status = %d, want %d", resp.Code, http.StatusServiceUnavailable)
+		}
+	})
+}
+
+type fakeMaintenanceService struct {
+	mu   sync.Mutex
+	last core.MaintenanceRequest
+}
+
+func (s *fakeMaintenanceService) State(context.Context) (core.MaintenanceState, error) {
+	return core.MaintenanceState{}, nil
+}
+
+func (s *fakeMaintenanceService) Pause(
+	_ context.Context,
+	req core.MaintenanceRequest,
+) (core.MaintenanceState, error) {
+	s.record(req)
+	if req.Workflow == "" {
+		return core.MaintenanceState{Paused: true}, nil
+	}
+	return core.MaintenanceState{}, nil
+}
+
+func (s *fakeMaintenanceService) Resume(
+	_ context.Context,
+	req core.MaintenanceRequest,
+) (core.MaintenanceState, error) {
+	s.record(req)
+	return core.MaintenanceState{}, nil
+}
+
+func (s *fakeMaintenanceService) record(req core.MaintenanceRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = req
+}
+
+func (s *fakeMaintenanceService) lastRequest() core.MaintenanceRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
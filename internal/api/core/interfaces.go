@@ -29,6 +29,7 @@ type HandlerConfig struct {
 	Runs            RunService
 	Sync            SyncService
 	Exec            ExecService
+	Maintenance     MaintenanceService
 }
 
 // DaemonService exposes daemon-wide status, health, metrics, and shutdown control.
@@ -39,6 +40,13 @@ type DaemonService interface {
 	Stop(context.Context, bool) error
 }
 
+// MaintenanceService exposes the persisted kill switch for new run starts.
+type MaintenanceService interface {
+	State(context.Context) (MaintenanceState, error)
+	Pause(context.Context, MaintenanceRequest) (MaintenanceState, error)
+	Resume(context.Context, MaintenanceRequest) (MaintenanceState, error)
+}
+
 // WorkspaceService exposes workspace registration and lookup.
 type WorkspaceService interface {
 	Register(context.Context, string, string) (WorkspaceRegisterResult, error)
@@ -89,6 +97,7 @@ type ReviewService interface {
 type RunService interface {
 	List(context.Context, RunListQuery) ([]Run, error)
 	Get(context.Context, string) (Run, error)
+	Wait(context.Context, string) (Run, error)
 	Snapshot(context.Context, string) (RunSnapshot, error)
 	Transcript(context.Context, string) (RunTranscript, error)
 	RunDetail(context.Context, string) (RunDetailPayload, error)
@@ -177,6 +186,9 @@ type MetricsPayload struct {
 
 type DaemonStatus = contract.DaemonStatus
 type DaemonHealth = contract.DaemonHealth
+type MaintenanceState = contract.MaintenanceState
+type MaintenanceWorkflow = contract.MaintenanceWorkflow
+type MaintenanceRequest = contract.MaintenanceRequest
 type HealthDetail = contract.HealthDetail
 type DaemonModeCount = contract.DaemonModeCount
 type DaemonDatabaseDiagnostics = contract.DaemonDatabaseDiagnostics
@@ -358,6 +370,10 @@ type RunJobMessageRequest = contract.RunJobMessageRequest
 type RunJobControlResponse = contract.RunJobControlResponse
 type RunShutdownState = contract.RunShutdownState
 type RunSnapshot = contract.RunSnapshot
+type RunComparison = contract.RunComparison
+type RunComparisonSide = contract.RunComparisonSide
+type RunJobComparison = contract.RunJobComparison
+type RunJobComparisonSide = contract.RunJobComparisonSide
 
 // RunJobCounts summarizes run jobs by status.
 type RunJobCounts struct {
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
+
 	"github.com/compozy/compozy/internal/core/reviews"
 	"github.com/compozy/compozy/internal/core/tasks"
 	"github.com/compozy/compozy/internal/store/globaldb"
@@ -163,6 +166,24 @@ func TestProblemAndHelperFunctions(t *testing.T) {
 			t.Fatal("requestCanceled(background) = true, want false")
 		}
 	})
+
+	t.Run("Should send Retry-After for problems that carry a retry hint", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/", http.NoBody)
+
+		problem := NewProblem(http.StatusTooManyRequests, "workspace_concurrency_limit", "busy", nil, nil)
+		problem.RetryAfter = 1500 * time.Millisecond
+		RespondError(c, problem)
+
+		if recorder.Code != http.StatusTooManyRequests {
+			t.Fatalf("status = %d, want 429", recorder.Code)
+		}
+		if got := recorder.Header().Get("Retry-After"); got != "2" {
+			t.Fatalf("Retry-After = %q, want 2", got)
+		}
+	})
 }
 
 func TestHandlerInternalHelpers(t *testing.T) {
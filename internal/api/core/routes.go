@@ -23,6 +23,9 @@ func registerDaemonRoutes(api gin.IRouter, handlers *Handlers) {
 	daemon.GET("/health", handlers.DaemonHealth)
 	daemon.GET("/metrics", handlers.DaemonMetrics)
 	daemon.POST("/stop", handlers.StopDaemon)
+	daemon.GET("/maintenance", handlers.GetMaintenance)
+	daemon.POST("/maintenance/pause", handlers.PauseMaintenance)
+	daemon.POST("/maintenance/resume", handlers.ResumeMaintenance)
 }
 
 func registerWorkspaceRoutes(api gin.IRouter, handlers *Handlers) {
@@ -73,6 +76,7 @@ func registerReviewRoutes(api gin.IRouter, handlers *Handlers) {
 func registerRunRoutes(api gin.IRouter, handlers *Handlers) {
 	runs := api.Group("/runs")
 	runs.GET("", handlers.ListRuns)
+	runs.GET("/compare", handlers.CompareRuns)
 	runs.GET("/:run_id", handlers.GetRun)
 	runs.GET("/:run_id/snapshot", handlers.GetRunSnapshot)
 	runs.GET("/:run_id/transcript", handlers.GetRunTranscript)
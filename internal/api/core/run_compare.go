@@ -0,0 +1,154 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/compozy/compozy/internal/api/contract"
+)
+
+// CompareRuns diffs two runs of the same workflow job by job. It serves
+// regression review after a prompt, model, or runtime change.
+func (h *Handlers) CompareRuns(c *gin.Context) {
+	if h.Runs == nil {
+		h.respondError(c, serviceUnavailableProblem("run service"))
+		return
+	}
+
+	runA := strings.TrimSpace(c.Query("a"))
+	runB := strings.TrimSpace(c.Query("b"))
+	if runA == "" || runB == "" {
+		h.respondError(c, validationProblem(
+			string(contract.CodeValidationError),
+			"both a and b run ids are required",
+			map[string]any{"field": "a,b"},
+		))
+		return
+	}
+
+	ctx := c.Request.Context()
+	snapshotA, err := h.Runs.Snapshot(ctx, runA)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	snapshotB, err := h.Runs.Snapshot(ctx, runB)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	comparison, err := CompareRunSnapshots(snapshotA, snapshotB)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contract.RunComparisonResponse{Comparison: comparison})
+}
+
+// CompareRunSnapshots pairs the jobs of two snapshots by task and reports
+// status, duration, and token usage differences. Both runs must share the
+// workspace, mode, and workflow.
+func CompareRunSnapshots(a RunSnapshot, b RunSnapshot) (RunComparison, error) {
+	if a.Run.WorkspaceID != b.Run.WorkspaceID ||
+		a.Run.Mode != b.Run.Mode ||
+		a.Run.WorkflowSlug != b.Run.WorkflowSlug {
+		return RunComparison{}, validationProblem(
+			string(contract.CodeRunCompareMismatch),
+			fmt.Sprintf(
+				"runs %s and %s are not runs of the same workflow",
+				a.Run.RunID,
+				b.Run.RunID,
+			),
+			map[string]any{
+				"a_workflow": a.Run.WorkflowSlug,
+				"b_workflow": b.Run.WorkflowSlug,
+				"a_mode":     a.Run.Mode,
+				"b_mode":     b.Run.Mode,
+			},
+		)
+	}
+
+	comparison := RunComparison{
+		A: runComparisonSide(a),
+		B: runComparisonSide(b),
+	}
+	byKey := make(map[string]int, len(a.Jobs)+len(b.Jobs))
+	entry := func(job RunJobState) *RunJobComparison {
+		key := runJobCompareKey(job)
+		if idx, ok := byKey[key]; ok {
+			return &comparison.Jobs[idx]
+		}
+		byKey[key] = len(comparison.Jobs)
+		comparison.Jobs = append(comparison.Jobs, RunJobComparison{Key: key})
+		return &comparison.Jobs[len(comparison.Jobs)-1]
+	}
+	for _, job := range a.Jobs {
+		item := entry(job)
+		item.Title = runJobCompareTitle(job)
+		item.A = runJobComparisonSide(job)
+	}
+	for _, job := range b.Jobs {
+		item := entry(job)
+		if item.Title == "" {
+			item.Title = runJobCompareTitle(job)
+		}
+		item.B = runJobComparisonSide(job)
+	}
+	for idx := range comparison.Jobs {
+		item := &comparison.Jobs[idx]
+		if item.A == nil || item.B == nil {
+			continue
+		}
+		item.StatusChanged = item.A.Status != item.B.Status
+		item.DurationDeltaMs = item.B.DurationMs - item.A.DurationMs
+		item.TotalTokensDelta = item.B.Usage.TotalTokens - item.A.Usage.TotalTokens
+	}
+	return comparison, nil
+}
+
+func runComparisonSide(snapshot RunSnapshot) RunComparisonSide {
+	side := RunComparisonSide{Run: snapshot.Run, Usage: snapshot.Usage}
+	if snapshot.Run.EndedAt != nil {
+		side.DurationMs = snapshot.Run.EndedAt.Sub(snapshot.Run.StartedAt).Milliseconds()
+	}
+	return side
+}
+
+func runJobComparisonSide(job RunJobState) *RunJobComparisonSide {
+	side := &RunJobComparisonSide{JobID: job.JobID, Status: job.Status}
+	if job.Summary != nil {
+		side.Model = job.Summary.Model
+		side.Attempt = job.Summary.Attempt
+		side.DurationMs = job.Summary.DurationMs
+		side.Usage = job.Summary.Usage
+	}
+	return side
+}
+
+// runJobCompareKey identifies the same unit of work across two runs. Job ids
+// are per-run, so the task id or the summary safe name is preferred.
+func runJobCompareKey(job RunJobState) string {
+	if taskID := strings.TrimSpace(job.TaskID); taskID != "" {
+		return taskID
+	}
+	if job.Summary != nil {
+		if safeName := strings.TrimSpace(job.Summary.SafeName); safeName != "" {
+			return safeName
+		}
+	}
+	return strings.TrimSpace(job.JobID)
+}
+
+func runJobCompareTitle(job RunJobState) string {
+	if job.Summary == nil {
+		return ""
+	}
+	if title := strings.TrimSpace(job.Summary.TaskTitle); title != "" {
+		return title
+	}
+	return strings.TrimSpace(job.Summary.CodeFile)
+}
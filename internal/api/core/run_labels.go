@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderRunLabels carries comma-separated key=value labels for run-start requests.
+const HeaderRunLabels = "X-Compozy-Labels"
+
+const (
+	maxRunLabels           = 32
+	maxRunLabelKeyLength   = 63
+	maxRunLabelValueLength = 256
+)
+
+var runLabelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+type runLabelsContextKey struct{}
+
+// WithRunLabels returns a child context carrying labels for the runs it starts.
+func WithRunLabels(ctx context.Context, labels map[string]string) context.Context {
+	if len(labels) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, runLabelsContextKey{}, maps.Clone(labels))
+}
+
+// RunLabelsFromContext returns a copy of the propagated run labels when available.
+func RunLabelsFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	labels, ok := ctx.Value(runLabelsContextKey{}).(map[string]string)
+	if !ok || len(labels) == 0 {
+		return nil
+	}
+	return maps.Clone(labels)
+}
+
+// ParseRunLabels parses comma-separated key=value pairs such as the
+// X-Compozy-Labels header or repeated label query parameters.
+func ParseRunLabels(values ...string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			key, labelValue, ok := strings.Cut(entry, "=")
+			if !ok {
+				return nil, labelsProblem(fmt.Sprintf("label %q must use key=value", entry), entry)
+			}
+			labels[strings.TrimSpace(key)] = strings.TrimSpace(labelValue)
+		}
+	}
+	return NormalizeRunLabels(labels)
+}
+
+// NormalizeRunLabels trims and validates run labels. Keys are 1-63 letters,
+// digits, '.', '_', '/', or '-'; values are at most 256 characters.
+func NormalizeRunLabels(labels map[string]string) (map[string]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	if len(labels) > maxRunLabels {
+		return nil, labelsProblem(fmt.Sprintf("at most %d labels are allowed", maxRunLabels), "")
+	}
+	normalized := make(map[string]string, len(labels))
+	for rawKey, rawValue := range labels {
+		key := strings.TrimSpace(rawKey)
+		value := strings.TrimSpace(rawValue)
+		if len(key) > maxRunLabelKeyLength || !runLabelKeyPattern.MatchString(key) {
+			return nil, labelsProblem(fmt.Sprintf("label key %q is invalid", rawKey), rawKey)
+		}
+		if len(value) > maxRunLabelValueLength {
+			return nil, labelsProblem(
+				fmt.Sprintf("label %q value exceeds %d characters", key, maxRunLabelValueLength),
+				key,
+			)
+		}
+		normalized[key] = value
+	}
+	return normalized, nil
+}
+
+// runStartContext merges header and body labels, with body values winning,
+// and returns the request context carrying them.
+func (h *Handlers) runStartContext(
+	c *gin.Context,
+	bodyLabels map[string]string,
+) (context.Context, map[string]string, bool) {
+	labels, err := ParseRunLabels(c.GetHeader(HeaderRunLabels))
+	if err != nil {
+		h.respondError(c, err)
+		return nil, nil, false
+	}
+	fromBody, err := NormalizeRunLabels(bodyLabels)
+	if err != nil {
+		h.respondError(c, err)
+		return nil, nil, false
+	}
+	if len(fromBody) > 0 {
+		if labels == nil {
+			labels = make(map[string]string, len(fromBody))
+		}
+		maps.Copy(labels, fromBody)
+	}
+	if len(labels) > maxRunLabels {
+		h.respondError(c, labelsProblem(fmt.Sprintf("at most %d labels are allowed", maxRunLabels), ""))
+		return nil, nil, false
+	}
+	return WithRunLabels(c.Request.Context(), labels), labels, true
+}
+
+func labelsProblem(message string, label string) error {
+	details := map[string]any{"field": "labels"}
+	if label != "" {
+		details["label"] = label
+	}
+	return validationProblem("labels_invalid", message, details)
+}
@@ -0,0 +1,155 @@
+package core_test
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/compozy/compozy/internal/api/core"
+)
+
+func TestStartExecRunMergesHeaderAndBodyLabels(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Should let body labels override header labels and propagate them on the context", func(t *testing.T) {
+		t.Parallel()
+
+		var gotReq core.ExecRequest
+		var gotCtxLabels map[string]string
+		engine := newRunLabelsEngine(&captureExecService{
+			start: func(ctx context.Context, req core.ExecRequest) (core.Run, error) {
+				gotReq = req
+				gotCtxLabels = core.RunLabelsFromContext(ctx)
+				return core.Run{RunID: "exec-1", Labels: req.Labels}, nil
+			},
+		})
+
+		response := serveRunLabelsRequest(
+			engine,
+			`{"workspace_path":"/tmp/ws","prompt":"hi","labels":{"env":"prod"}}`,
+			"team=core, env=dev",
+		)
+		if response.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d; body=%s", response.Code, http.StatusCreated, response.Body.String())
+		}
+		want := map[string]string{"team": "core", "env": "prod"}
+		if !maps.Equal(gotReq.Labels, want) {
+			t.Fatalf("request labels = %#v, want %#v", gotReq.Labels, want)
+		}
+		if !maps.Equal(gotCtxLabels, want) {
+			t.Fatalf("context labels = %#v, want %#v", gotCtxLabels, want)
+		}
+	})
+
+	t.Run("Should reject malformed labels before starting the run", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tc := range []struct {
+			name   string
+			body   string
+			header string
+		}{
+			{name: "header without value", body: `{"workspace_path":"/tmp/ws","prompt":"hi"}`, header: "team"},
+			{
+				name: "invalid body key",
+				body: `{"workspace_path":"/tmp/ws","prompt":"hi","labels":{"bad key":"x"}}`,
+			},
+		} {
+			started := false
+			engine := newRunLabelsEngine(&captureExecService{
+				start: func(context.Context, core.ExecRequest) (core.Run, error) {
+					started = true
+					return core.Run{}, nil
+				},
+			})
+			response := serveRunLabelsRequest(engine, tc.body, tc.header)
+			if response.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("%s: status = %d, want %d", tc.name, response.Code, http.StatusUnprocessableEntity)
+			}
+			if !strings.Contains(response.Body.String(), "labels_invalid") {
+				t.Fatalf("%s: body = %s, want labels_invalid", tc.name, response.Body.String())
+			}
+			if started {
+				t.Fatalf("%s: exec service started despite invalid labels", tc.name)
+			}
+		}
+	})
+}
+
+func TestListRunsParsesLabelFilters(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	var got core.RunListQuery
+	handlers := core.NewHandlers(&core.HandlerConfig{
+		TransportName: "test",
+		Runs: &fakeRunService{
+			list: func(_ context.Context, query core.RunListQuery) ([]core.Run, error) {
+				got = query
+				return nil, nil
+			},
+		},
+	})
+	engine := gin.New()
+	engine.Use(core.RequestIDMiddleware())
+	engine.Use(core.ErrorMiddleware())
+	core.RegisterRoutes(engine, handlers)
+
+	request := httptest.NewRequestWithContext(
+		context.Background(),
+		http.MethodGet,
+		"/api/runs?label=team%3Dcore&label=env%3Dprod",
+		http.NoBody,
+	)
+	response := httptest.NewRecorder()
+	engine.ServeHTTP(response, request)
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body=%s", response.Code, http.StatusOK, response.Body.String())
+	}
+	want := map[string]string{"team": "core", "env": "prod"}
+	if !maps.Equal(got.Labels, want) {
+		t.Fatalf("labels = %#v, want %#v", got.Labels, want)
+	}
+}
+
+type captureExecService struct {
+	start func(context.Context, core.ExecRequest) (core.Run, error)
+}
+
+func (s *captureExecService) Start(ctx context.Context, req core.ExecRequest) (core.Run, error) {
+	return s.start(ctx, req)
+}
+
+func newRunLabelsEngine(exec core.ExecService) *gin.Engine {
+	handlers := core.NewHandlers(&core.HandlerConfig{TransportName: "test", Exec: exec})
+	engine := gin.New()
+	engine.Use(core.RequestIDMiddleware())
+	engine.Use(core.ErrorMiddleware())
+	core.RegisterRoutes(engine, handlers)
+	return engine
+}
+
+func serveRunLabelsRequest(engine *gin.Engine, body string, header string) *httptest.ResponseRecorder {
+	request := httptest.NewRequestWithContext(
+		context.Background(),
+		http.MethodPost,
+		"/api/exec",
+		strings.NewReader(body),
+	)
+	request.Header.Set("Content-Type", "application/json")
+	if header != "" {
+		request.Header.Set(core.HeaderRunLabels, header)
+	}
+	response := httptest.NewRecorder()
+	engine.ServeHTTP(response, request)
+	return response
+}
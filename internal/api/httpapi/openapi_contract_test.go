@@ -29,16 +29,20 @@ var openAPIOperationMethods = map[string]struct{}{
 }
 
 var browserRouteExclusions = map[string]struct{}{
-	"DELETE /api/workspaces/{id}":     {},
-	"GET /api/runs/{run_id}/events":   {},
-	"GET /api/tasks/{slug}/items":     {},
-	"GET /api/workspaces/{id}":        {},
-	"PATCH /api/workspaces/{id}":      {},
-	"POST /api/daemon/stop":           {},
-	"POST /api/exec":                  {},
-	"POST /api/reviews/{slug}/fetch":  {},
-	"POST /api/tasks/{slug}/validate": {},
-	"POST /api/workspaces":            {},
+	"DELETE /api/workspaces/{id}":         {},
+	"GET /api/daemon/maintenance":         {},
+	"GET /api/runs/compare":               {},
+	"GET /api/runs/{run_id}/events":       {},
+	"GET /api/tasks/{slug}/items":         {},
+	"GET /api/workspaces/{id}":            {},
+	"PATCH /api/workspaces/{id}":          {},
+	"POST /api/daemon/maintenance/pause":  {},
+	"POST /api/daemon/maintenance/resume": {},
+	"POST /api/daemon/stop":               {},
+	"POST /api/exec":                      {},
+	"POST /api/reviews/{slug}/fetch":      {},
+	"POST /api/tasks/{slug}/validate":     {},
+	"POST /api/workspaces":                {},
 }
 
 func TestBrowserOpenAPIContractMatchesRegisteredBrowserRoutes(t *testing.T) {
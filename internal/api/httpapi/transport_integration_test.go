@@ -2183,6 +2183,10 @@ func (f *fakeRunService) Get(_ context.Context, runID string) (core.Run, error)
 	return item, nil
 }
 
+func (f *fakeRunService) Wait(ctx context.Context, runID string) (core.Run, error) {
+	return f.Get(ctx, runID)
+}
+
 func (f *fakeRunService) Snapshot(_ context.Context, runID string) (core.RunSnapshot, error) {
 	item, ok := f.snapshots[runID]
 	if !ok {
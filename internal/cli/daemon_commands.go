@@ -84,6 +84,7 @@ type daemonCommandClient interface {
 		apicore.RunJobMessageRequest,
 	) (apicore.RunJobControlResponse, error)
 	GetRunSnapshot(context.Context, string) (apicore.RunSnapshot, error)
+	CompareRuns(context.Context, string, string) (apicore.RunComparison, error)
 	ListRunEvents(context.Context, string, apicore.StreamCursor, int) (apicore.RunEventPage, error)
 	OpenRunStream(context.Context, string, apicore.StreamCursor) (apiclient.RunStream, error)
 }
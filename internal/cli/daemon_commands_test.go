@@ -497,6 +497,22 @@ func (c *stubDaemonCommandClient) GetRunSnapshot(ctx context.Context, runID stri
 	return c.snapshot, nil
 }
 
+func (c *stubDaemonCommandClient) CompareRuns(
+	ctx context.Context,
+	runA string,
+	runB string,
+) (apicore.RunComparison, error) {
+	snapshotA, err := c.GetRunSnapshot(ctx, runA)
+	if err != nil {
+		return apicore.RunComparison{}, err
+	}
+	snapshotB, err := c.GetRunSnapshot(ctx, runB)
+	if err != nil {
+		return apicore.RunComparison{}, err
+	}
+	return apicore.CompareRunSnapshots(snapshotA, snapshotB)
+}
+
 func (c *stubDaemonCommandClient) ListRunEvents(
 	context.Context,
 	string,
@@ -499,6 +499,22 @@ func (c *inProcessDaemonCommandClient) GetRunSnapshot(ctx context.Context, runID
 	return c.manager.Snapshot(ctx, runID)
 }
 
+func (c *inProcessDaemonCommandClient) CompareRuns(
+	ctx context.Context,
+	runA string,
+	runB string,
+) (apicore.RunComparison, error) {
+	snapshotA, err := c.manager.Snapshot(ctx, runA)
+	if err != nil {
+		return apicore.RunComparison{}, err
+	}
+	snapshotB, err := c.manager.Snapshot(ctx, runB)
+	if err != nil {
+		return apicore.RunComparison{}, err
+	}
+	return apicore.CompareRunSnapshots(snapshotA, snapshotB)
+}
+
 func (c *inProcessDaemonCommandClient) ListRunEvents(
 	ctx context.Context,
 	runID string,
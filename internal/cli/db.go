@@ -0,0 +1,634 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	compozyconfig "github.com/compozy/compozy/internal/config"
+	"github.com/compozy/compozy/internal/core/model"
+	"github.com/compozy/compozy/internal/daemon"
+	"github.com/compozy/compozy/internal/store"
+	"github.com/compozy/compozy/internal/store/globaldb"
+	"github.com/compozy/compozy/internal/store/rundb"
+	"github.com/spf13/cobra"
+)
+
+// dbTarget names one SQLite store the db commands operate on.
+type dbTarget struct {
+	label    string
+	path     string
+	paths    compozyconfig.HomePaths
+	migrator *store.Migrator
+}
+
+type dbMigrationStatusOutput struct {
+	Database       string                 `json:"database"`
+	Path           string                 `json:"path"`
+	CurrentVersion int                    `json:"current_version"`
+	LatestVersion  int                    `json:"latest_version"`
+	Migrations     []dbMigrationStatusRow `json:"migrations"`
+}
+
+type dbMigrationStatusRow struct {
+	Version    int    `json:"version"`
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	AppliedAt  string `json:"applied_at,omitempty"`
+	Checksum   string `json:"checksum,omitempty"`
+	Recorded   string `json:"recorded_checksum,omitempty"`
+	Reversible bool   `json:"reversible"`
+}
+
+func newDBCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "db",
+		Short:        "Inspect and maintain the home-scoped SQLite stores",
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(
+		newDBMigrateCommand(),
+		newDBCheckCommand(),
+		newDBBackupCommand(),
+		newDBRestoreCommand(),
+		newDBQueryCommand(),
+	)
+	return cmd
+}
+
+func newDBCheckCommand() *cobra.Command {
+	var (
+		runID        string
+		outputFormat string
+	)
+	cmd := &cobra.Command{
+		Use:          "check",
+		Short:        "Compare the live schema with the applied migrations and report drift",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		Long: `Compare the live schema with the schema the applied migrations produce.
+
+Tables, columns, indexes, views, and triggers added or altered outside the
+migrations (for example by a manual hotfix) are reported as unexpected, missing,
+or changed. The command exits non-zero when drift is found.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := normalizeOperatorOutputFormat(outputFormat)
+			if err != nil {
+				return withExitCode(1, err)
+			}
+
+			ctx, stop := signalCommandContext(cmd)
+			defer stop()
+
+			target, err := resolveDBTarget(runID)
+			if err != nil {
+				return err
+			}
+			db, err := openDBTarget(ctx, target)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+
+			report, err := target.migrator.CheckSchemaDrift(ctx, db)
+			if err != nil {
+				return err
+			}
+			report.Scope = target.label
+			if format == operatorOutputFormatJSON {
+				err = writeOperatorJSON(cmd.OutOrStdout(), report)
+			} else {
+				err = writeDBSchemaDriftText(cmd.OutOrStdout(), report)
+			}
+			if err != nil {
+				return err
+			}
+			if !report.Clean() {
+				return withExitCode(1, fmt.Errorf("%s schema drift: %d difference(s)", target.label, len(report.Drifts)))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&runID, "run", "", "Check the run.db of this run instead of global.db")
+	cmd.Flags().StringVar(&outputFormat, "format", operatorOutputFormatText, "Output format: text or json")
+	return cmd
+}
+
+func newDBBackupCommand() *cobra.Command {
+	var runID string
+	cmd := &cobra.Command{
+		Use:          "backup <path>",
+		Short:        "Write a consistent snapshot of global.db or one run.db to <path>",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		Long: `Write a consistent snapshot of global.db, or of one run's run.db with --run.
+
+The snapshot is taken with VACUUM INTO, so it is safe while the daemon is running
+and writing. The command refuses to overwrite an existing file at <path>.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signalCommandContext(cmd)
+			defer stop()
+
+			target, err := resolveDBTarget(runID)
+			if err != nil {
+				return err
+			}
+			db, err := openDBTarget(ctx, target)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+
+			backupPath, err := filepath.Abs(strings.TrimSpace(args[0]))
+			if err != nil {
+				return fmt.Errorf("resolve backup path: %w", err)
+			}
+			if err := store.BackupSQLiteDatabase(ctx, db, backupPath); err != nil {
+				return withExitCode(1, err)
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "backed up %s to %s\n", target.label, backupPath)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&runID, "run", "", "Back up the run.db of this run instead of global.db")
+	return cmd
+}
+
+func newDBRestoreCommand() *cobra.Command {
+	var runID string
+	cmd := &cobra.Command{
+		Use:          "restore <path>",
+		Short:        "Replace global.db or one run.db with a backup",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		Long: `Replace global.db, or one run's run.db with --run, with the backup at <path>.
+
+The backup must pass an integrity check. The current database is moved aside
+with a .pre-restore.<timestamp> suffix rather than deleted. The command refuses
+to run while the daemon is running, because the daemon keeps its stores open.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signalCommandContext(cmd)
+			defer stop()
+
+			target, err := resolveDBTarget(runID)
+			if err != nil {
+				return err
+			}
+			if err := requireDaemonStopped(ctx, target, "restoring the "+target.label); err != nil {
+				return err
+			}
+
+			backupPath, err := filepath.Abs(strings.TrimSpace(args[0]))
+			if err != nil {
+				return fmt.Errorf("resolve backup path: %w", err)
+			}
+			replacedPath, err := store.RestoreSQLiteDatabase(ctx, backupPath, target.path)
+			if err != nil {
+				return withExitCode(1, err)
+			}
+			if _, err := fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"restored %s from %s\n",
+				target.label,
+				backupPath,
+			); err != nil {
+				return err
+			}
+			if replacedPath == "" {
+				return nil
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "previous database moved to %s\n", replacedPath)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&runID, "run", "", "Restore the run.db of this run instead of global.db")
+	return cmd
+}
+
+func newDBQueryCommand() *cobra.Command {
+	var (
+		runID        string
+		maxRows      int
+		timeout      time.Duration
+		outputFormat string
+	)
+	cmd := &cobra.Command{
+		Use:          "query <sql>",
+		Short:        "Run a read-only SQL query against global.db or one run.db",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		Long: `Run one read-only SQL query against global.db, or one run's run.db with --run.
+The database is opened read-only with query_only set, so statements that write
+fail. At most --max-rows rows are printed and the query is interrupted after
+--timeout. It is safe while the daemon is running.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := normalizeOperatorOutputFormat(outputFormat)
+			if err != nil {
+				return withExitCode(1, err)
+			}
+			if maxRows <= 0 {
+				return withExitCode(1, fmt.Errorf("--max-rows must be positive (got %d)", maxRows))
+			}
+			if timeout <= 0 {
+				return withExitCode(1, fmt.Errorf("--timeout must be positive (got %s)", timeout))
+			}
+
+			ctx, stop := signalCommandContext(cmd)
+			defer stop()
+
+			target, err := resolveDBTarget(runID)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(target.path); err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return withExitCode(1, fmt.Errorf("%s database does not exist at %s", target.label, target.path))
+				}
+				return fmt.Errorf("stat %s database: %w", target.label, err)
+			}
+
+			queryCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			result, err := store.QuerySQLiteReadOnly(queryCtx, target.path, args[0], maxRows)
+			if err != nil {
+				if errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+					return withExitCode(1, fmt.Errorf("query exceeded --timeout %s", timeout))
+				}
+				return withExitCode(1, err)
+			}
+			if format == operatorOutputFormatJSON {
+				return writeOperatorJSON(cmd.OutOrStdout(), result)
+			}
+			return writeDBQueryText(cmd.OutOrStdout(), result, maxRows)
+		},
+	}
+	cmd.Flags().StringVar(&runID, "run", "", "Query the run.db of this run instead of global.db")
+	cmd.Flags().IntVar(&maxRows, "max-rows", 1000, "Maximum number of rows to return")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Interrupt the query after this long")
+	cmd.Flags().StringVar(&outputFormat, "format", operatorOutputFormatText, "Output format: text or json")
+	return cmd
+}
+
+func newDBMigrateCommand() *cobra.Command {
+	var runID string
+	cmd := &cobra.Command{
+		Use:          "migrate",
+		Short:        "Report, apply, or roll back schema migrations for global.db or one run.db",
+		SilenceUsage: true,
+		Long: `Report, apply, or roll back schema migrations.
+
+Commands target ~/.compozy/db/global.db by default; pass --run <run-id> to target
+that run's run.db instead. Commands that change the schema refuse to run while the
+daemon is running, because the daemon keeps its stores open.`,
+	}
+	cmd.PersistentFlags().StringVar(&runID, "run", "", "Target the run.db of this run instead of global.db")
+
+	cmd.AddCommand(
+		newDBMigrateStatusCommand(&runID),
+		newDBMigrateChangeCommand(&runID, dbMigrateChangeSpec{
+			use:   "up",
+			short: "Apply every pending migration",
+			args:  cobra.NoArgs,
+			apply: func(ctx context.Context, target dbTarget, db *sql.DB, _ []string) error {
+				return target.migrator.Up(ctx, db)
+			},
+		}),
+		newDBMigrateDownCommand(&runID),
+		newDBMigrateChangeCommand(&runID, dbMigrateChangeSpec{
+			use:   "to <version>",
+			short: "Apply or roll back migrations until <version> is the newest applied (0 reverts all)",
+			args:  cobra.ExactArgs(1),
+			apply: func(ctx context.Context, target dbTarget, db *sql.DB, args []string) error {
+				version, err := strconv.Atoi(strings.TrimSpace(args[0]))
+				if err != nil || version < 0 {
+					return withExitCode(1, fmt.Errorf("migration version must be a non-negative integer (got %q)", args[0]))
+				}
+				return target.migrator.MigrateTo(ctx, db, version)
+			},
+		}),
+	)
+	return cmd
+}
+
+func newDBMigrateStatusCommand(runID *string) *cobra.Command {
+	var outputFormat string
+	cmd := &cobra.Command{
+		Use:          "status",
+		Short:        "List applied, pending, and drifted migrations",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := normalizeOperatorOutputFormat(outputFormat)
+			if err != nil {
+				return withExitCode(1, err)
+			}
+
+			ctx, stop := signalCommandContext(cmd)
+			defer stop()
+
+			target, err := resolveDBTarget(*runID)
+			if err != nil {
+				return err
+			}
+			db, err := openDBTarget(ctx, target)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+
+			states, err := target.migrator.Status(ctx, db)
+			if err != nil {
+				return err
+			}
+			output := buildDBMigrationStatusOutput(target, states)
+			if format == operatorOutputFormatJSON {
+				return writeOperatorJSON(cmd.OutOrStdout(), output)
+			}
+			return writeDBMigrationStatusText(cmd.OutOrStdout(), output)
+		},
+	}
+	cmd.Flags().StringVar(&outputFormat, "format", operatorOutputFormatText, "Output format: text or json")
+	return cmd
+}
+
+func newDBMigrateDownCommand(runID *string) *cobra.Command {
+	var steps int
+	cmd := newDBMigrateChangeCommand(runID, dbMigrateChangeSpec{
+		use:   "down",
+		short: "Roll back the newest applied migrations",
+		args:  cobra.NoArgs,
+		apply: func(ctx context.Context, target dbTarget, db *sql.DB, _ []string) error {
+			if steps <= 0 {
+				return withExitCode(1, fmt.Errorf("--steps must be positive (got %d)", steps))
+			}
+			return target.migrator.Down(ctx, db, steps)
+		},
+	})
+	cmd.Flags().IntVar(&steps, "steps", 1, "Number of applied migrations to roll back")
+	return cmd
+}
+
+type dbMigrateChangeSpec struct {
+	use   string
+	short string
+	args  cobra.PositionalArgs
+	apply func(context.Context, dbTarget, *sql.DB, []string) error
+}
+
+func newDBMigrateChangeCommand(runID *string, spec dbMigrateChangeSpec) *cobra.Command {
+	return &cobra.Command{
+		Use:          spec.use,
+		Short:        spec.short,
+		SilenceUsage: true,
+		Args:         spec.args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signalCommandContext(cmd)
+			defer stop()
+
+			target, err := resolveDBTarget(*runID)
+			if err != nil {
+				return err
+			}
+			if err := requireDaemonStopped(ctx, target, "changing the "+target.label+" schema"); err != nil {
+				return err
+			}
+			db, err := openDBTarget(ctx, target)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+
+			if err := spec.apply(ctx, target, db, args); err != nil {
+				return err
+			}
+			states, err := target.migrator.Status(ctx, db)
+			if err != nil {
+				return err
+			}
+			output := buildDBMigrationStatusOutput(target, states)
+			_, err = fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"%s at version %d (latest %d)\n",
+				output.Database,
+				output.CurrentVersion,
+				output.LatestVersion,
+			)
+			return err
+		},
+	}
+}
+
+func resolveDBTarget(runID string) (dbTarget, error) {
+	paths, err := compozyconfig.ResolveHomePaths()
+	if err != nil {
+		return dbTarget{}, err
+	}
+
+	runID = strings.TrimSpace(runID)
+	if runID == "" {
+		return dbTarget{
+			label:    "global catalog",
+			path:     paths.GlobalDBPath,
+			paths:    paths,
+			migrator: globaldb.Migrator(),
+		}, nil
+	}
+	if strings.ContainsAny(runID, `/\`) || runID == "." || runID == ".." {
+		return dbTarget{}, withExitCode(1, fmt.Errorf("invalid run id %q", runID))
+	}
+	return dbTarget{
+		label:    fmt.Sprintf("run %s", runID),
+		path:     model.NewRunArtifactsForRunsDir(paths.RunsDir, runID).RunDBPath,
+		paths:    paths,
+		migrator: rundb.Migrator(),
+	}, nil
+}
+
+// openDBTarget opens an existing store without applying migrations, so the
+// commands can report and change the schema explicitly.
+func openDBTarget(ctx context.Context, target dbTarget) (*sql.DB, error) {
+	if _, err := os.Stat(target.path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, withExitCode(1, fmt.Errorf("%s database does not exist at %s", target.label, target.path))
+		}
+		return nil, fmt.Errorf("stat %s database: %w", target.label, err)
+	}
+	return store.OpenSQLiteDatabase(ctx, target.path, nil)
+}
+
+func requireDaemonStopped(ctx context.Context, target dbTarget, action string) error {
+	status, err := queryDaemonCommandStatus(ctx, target.paths, daemon.ProbeOptions{})
+	if err != nil {
+		return fmt.Errorf("query daemon status: %w", err)
+	}
+	if status.State == daemon.ReadyStateStopped {
+		return nil
+	}
+	pid := 0
+	if status.Info != nil {
+		pid = status.Info.PID
+	}
+	return withExitCode(2, fmt.Errorf(
+		"daemon is %s (pid %d); stop it with `compozy daemon stop` before %s",
+		status.State,
+		pid,
+		action,
+	))
+}
+
+func buildDBMigrationStatusOutput(target dbTarget, states []store.MigrationState) dbMigrationStatusOutput {
+	output := dbMigrationStatusOutput{
+		Database:      target.label,
+		Path:          target.path,
+		LatestVersion: target.migrator.Latest(),
+		Migrations:    make([]dbMigrationStatusRow, 0, len(states)),
+	}
+	for _, state := range states {
+		row := dbMigrationStatusRow{
+			Version:    state.Version,
+			Name:       state.Name,
+			State:      dbMigrationStateLabel(state),
+			Checksum:   state.Checksum,
+			Recorded:   state.AppliedChecksum,
+			Reversible: state.Reversible,
+		}
+		if state.Applied {
+			output.CurrentVersion = max(output.CurrentVersion, state.Version)
+			if !state.AppliedAt.IsZero() {
+				row.AppliedAt = state.AppliedAt.UTC().Format(time.RFC3339)
+			}
+		}
+		output.Migrations = append(output.Migrations, row)
+	}
+	return output
+}
+
+func dbMigrationStateLabel(state store.MigrationState) string {
+	switch {
+	case state.Drifted():
+		return "drifted"
+	case state.Applied && !state.Known:
+		return "unknown"
+	case state.Applied:
+		return "applied"
+	default:
+		return "pending"
+	}
+}
+
+func writeDBMigrationStatusText(out io.Writer, output dbMigrationStatusOutput) error {
+	if _, err := fmt.Fprintf(
+		out,
+		"%s: %s\nversion %d (latest %d)\n\n",
+		output.Database,
+		output.Path,
+		output.CurrentVersion,
+		output.LatestVersion,
+	); err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(writer, "VERSION\tNAME\tSTATE\tCHECKSUM\tAPPLIED AT\tREVERSIBLE"); err != nil {
+		return err
+	}
+	for _, row := range output.Migrations {
+		appliedAt := row.AppliedAt
+		if appliedAt == "" {
+			appliedAt = "-"
+		}
+		if _, err := fmt.Fprintf(
+			writer,
+			"%d\t%s\t%s\t%s\t%s\t%t\n",
+			row.Version,
+			row.Name,
+			row.State,
+			shortDBChecksum(row),
+			appliedAt,
+			row.Reversible,
+		); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// shortDBChecksum prints the recorded checksum when it disagrees with the binary.
+func shortDBChecksum(row dbMigrationStatusRow) string {
+	value := row.Checksum
+	if row.State == "drifted" || value == "" {
+		value = row.Recorded
+	}
+	if len(value) > 12 {
+		value = value[:12]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func writeDBSchemaDriftText(out io.Writer, report store.SchemaDriftReport) error {
+	if report.Clean() {
+		_, err := fmt.Fprintf(out, "%s: schema matches migration version %d\n", report.Scope, report.Version)
+		return err
+	}
+	if _, err := fmt.Fprintf(
+		out,
+		"%s: schema drift against migration version %d\n",
+		report.Scope,
+		report.Version,
+	); err != nil {
+		return err
+	}
+	for _, drift := range report.Drifts {
+		if _, err := fmt.Fprintf(out, "  %s\n", drift.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeDBQueryText(out io.Writer, result store.QueryResult, maxRows int) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(writer, strings.Join(result.Columns, "\t")); err != nil {
+		return err
+	}
+	for _, row := range result.Rows {
+		cells := make([]string, len(row))
+		for idx, value := range row {
+			if value == nil {
+				cells[idx] = "NULL"
+				continue
+			}
+			cells[idx] = fmt.Sprint(value)
+		}
+		if _, err := fmt.Fprintln(writer, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if !result.Truncated {
+		return nil
+	}
+	_, err := fmt.Fprintf(out, "(stopped at --max-rows %d)\n", maxRows)
+	return err
+}
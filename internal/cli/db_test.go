@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	compozyconfig "github.com/compozy/compozy/internal/config"
+	"github.com/compozy/compozy/internal/daemon"
+	"github.com/compozy/compozy/internal/store"
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+func TestDBMigrateCommandsRollBackAndReapplyGlobalCatalog(t *testing.T) {
+	paths := seedDBCommandHome(t)
+	latest := globaldb.Migrator().Latest()
+
+	output, err := executeRootCommand("db", "migrate", "status")
+	if err != nil {
+		t.Fatalf("execute db migrate status: %v\noutput:\n%s", err, output)
+	}
+	for _, snippet := range []string{"global catalog: " + paths.GlobalDBPath, "VERSION", "applied"} {
+		if !strings.Contains(output, snippet) {
+			t.Fatalf("expected status output to include %q\noutput:\n%s", snippet, output)
+		}
+	}
+
+	output, err = executeRootCommand("db", "migrate", "down", "--steps", "2")
+	if err != nil {
+		t.Fatalf("execute db migrate down: %v\noutput:\n%s", err, output)
+	}
+	if got := loadDBMigrationStatusJSON(t); got.CurrentVersion != latest-2 {
+		t.Fatalf("current version after down = %d, want %d", got.CurrentVersion, latest-2)
+	}
+
+	output, err = executeRootCommand("db", "migrate", "to", "0")
+	if err != nil {
+		t.Fatalf("execute db migrate to 0: %v\noutput:\n%s", err, output)
+	}
+	if !strings.Contains(output, "global catalog at version 0") {
+		t.Fatalf("unexpected migrate to output: %q", output)
+	}
+
+	if _, err := executeRootCommand("db", "migrate", "up"); err != nil {
+		t.Fatalf("execute db migrate up: %v", err)
+	}
+	status := loadDBMigrationStatusJSON(t)
+	if status.CurrentVersion != latest {
+		t.Fatalf("current version after up = %d, want %d", status.CurrentVersion, latest)
+	}
+	for _, row := range status.Migrations {
+		if row.State != "applied" {
+			t.Fatalf("migration %d state = %q, want applied", row.Version, row.State)
+		}
+	}
+
+	db, err := globaldb.Open(context.Background(), paths.GlobalDBPath)
+	if err != nil {
+		t.Fatalf("globaldb.Open() after reapply error = %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestDBMigrateCommandsRejectInvalidTargets(t *testing.T) {
+	seedDBCommandHome(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "unknown version", args: []string{"db", "migrate", "to", "999"}, wantErr: "no migration 999"},
+		{name: "non-numeric version", args: []string{"db", "migrate", "to", "latest"}, wantErr: "non-negative integer"},
+		{name: "missing run database", args: []string{"db", "migrate", "status", "--run", "run-missing"}, wantErr: "does not exist"},
+		{name: "path-like run id", args: []string{"db", "migrate", "status", "--run", "../escape"}, wantErr: "invalid run id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := executeRootCommand(tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("execute %v error = %v, want %q\noutput:\n%s", tt.args, err, tt.wantErr, output)
+			}
+		})
+	}
+}
+
+func TestDBMigrateChangesRequireStoppedDaemon(t *testing.T) {
+	seedDBCommandHome(t)
+
+	originalQueryStatus := queryDaemonCommandStatus
+	queryDaemonCommandStatus = func(
+		context.Context,
+		compozyconfig.HomePaths,
+		daemon.ProbeOptions,
+	) (daemon.Status, error) {
+		return daemon.Status{State: daemon.ReadyStateReady, Info: &daemon.Info{PID: 4242}}, nil
+	}
+	t.Cleanup(func() {
+		queryDaemonCommandStatus = originalQueryStatus
+	})
+
+	_, err := executeRootCommand("db", "migrate", "down")
+	if err == nil || !strings.Contains(err.Error(), "compozy daemon stop") {
+		t.Fatalf("execute db migrate down error = %v, want daemon running refusal", err)
+	}
+	var exitErr *commandExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 2 {
+		t.Fatalf("execute db migrate down error = %#v, want exit code 2", err)
+	}
+	if got := loadDBMigrationStatusJSON(t); got.CurrentVersion != globaldb.Migrator().Latest() {
+		t.Fatalf("current version = %d, want schema untouched", got.CurrentVersion)
+	}
+}
+
+func seedDBCommandHome(t *testing.T) compozyconfig.HomePaths {
+	t.Helper()
+
+	t.Setenv(compozyconfig.HomeEnvVar, t.TempDir())
+	paths, err := compozyconfig.ResolveHomePaths()
+	if err != nil {
+		t.Fatalf("ResolveHomePaths() error = %v", err)
+	}
+	if err := compozyconfig.EnsureHomeLayout(paths); err != nil {
+		t.Fatalf("EnsureHomeLayout() error = %v", err)
+	}
+	db, err := globaldb.Open(context.Background(), paths.GlobalDBPath)
+	if err != nil {
+		t.Fatalf("globaldb.Open() error = %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return paths
+}
+
+func loadDBMigrationStatusJSON(t *testing.T) dbMigrationStatusOutput {
+	t.Helper()
+
+	output, err := executeRootCommand("db", "migrate", "status", "--format", "json")
+	if err != nil {
+		t.Fatalf("execute db migrate status --format json: %v\noutput:\n%s", err, output)
+	}
+	var status dbMigrationStatusOutput
+	if err := json.Unmarshal([]byte(output), &status); err != nil {
+		t.Fatalf("decode status output: %v\noutput:\n%s", err, output)
+	}
+	return status
+}
+
+func TestDBCheckCommandReportsSchemaDrift(t *testing.T) {
+	paths := seedDBCommandHome(t)
+
+	output, err := executeRootCommand("db", "check")
+	if err != nil {
+		t.Fatalf("execute db check: %v\noutput:\n%s", err, output)
+	}
+	if !strings.Contains(output, "global catalog: schema matches migration version") {
+		t.Fatalf("unexpected clean db check output: %q", output)
+	}
+
+	db, err := store.OpenSQLiteDatabase(context.Background(), paths.GlobalDBPath, nil)
+	if err != nil {
+		t.Fatalf("OpenSQLiteDatabase() error = %v", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `ALTER TABLE runs ADD COLUMN hotfix TEXT`); err != nil {
+		t.Fatalf("apply hotfix column: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	output, err = executeRootCommand("db", "check")
+	if err == nil || !strings.Contains(err.Error(), "schema drift: 1 difference(s)") {
+		t.Fatalf("execute db check error = %v, want drift failure\noutput:\n%s", err, output)
+	}
+	if !strings.Contains(output, "unexpected column runs.hotfix: TEXT") {
+		t.Fatalf("expected drift listing in output:\n%s", output)
+	}
+}
+
+func TestDBBackupAndRestoreCommandsRoundTripGlobalCatalog(t *testing.T) {
+	paths := seedDBCommandHome(t)
+	backupPath := filepath.Join(t.TempDir(), "global-backup.db")
+
+	output, err := executeRootCommand("db", "backup", backupPath)
+	if err != nil {
+		t.Fatalf("execute db backup: %v\noutput:\n%s", err, output)
+	}
+	if !strings.Contains(output, "backed up global catalog to "+backupPath) {
+		t.Fatalf("unexpected backup output: %q", output)
+	}
+
+	if _, err := executeRootCommand("db", "migrate", "down"); err != nil {
+		t.Fatalf("execute db migrate down: %v", err)
+	}
+
+	output, err = executeRootCommand("db", "restore", backupPath)
+	if err != nil {
+		t.Fatalf("execute db restore: %v\noutput:\n%s", err, output)
+	}
+	for _, snippet := range []string{
+		"restored global catalog from " + backupPath,
+		"previous database moved to " + paths.GlobalDBPath + ".pre-restore.",
+	} {
+		if !strings.Contains(output, snippet) {
+			t.Fatalf("expected restore output to include %q\noutput:\n%s", snippet, output)
+		}
+	}
+	if got := loadDBMigrationStatusJSON(t); got.CurrentVersion != globaldb.Migrator().Latest() {
+		t.Fatalf("current version after restore = %d, want %d", got.CurrentVersion, globaldb.Migrator().Latest())
+	}
+}
+
+func TestDBRestoreRequiresStoppedDaemon(t *testing.T) {
+	seedDBCommandHome(t)
+	backupPath := filepath.Join(t.TempDir(), "global-backup.db")
+	if _, err := executeRootCommand("db", "backup", backupPath); err != nil {
+		t.Fatalf("execute db backup: %v", err)
+	}
+
+	originalQueryStatus := queryDaemonCommandStatus
+	queryDaemonCommandStatus = func(
+		context.Context,
+		compozyconfig.HomePaths,
+		daemon.ProbeOptions,
+	) (daemon.Status, error) {
+		return daemon.Status{State: daemon.ReadyStateReady, Info: &daemon.Info{PID: 4242}}, nil
+	}
+	t.Cleanup(func() {
+		queryDaemonCommandStatus = originalQueryStatus
+	})
+
+	_, err := executeRootCommand("db", "restore", backupPath)
+	if err == nil || !strings.Contains(err.Error(), "compozy daemon stop") {
+		t.Fatalf("execute db restore error = %v, want daemon running refusal", err)
+	}
+	var exitErr *commandExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 2 {
+		t.Fatalf("execute db restore error = %#v, want exit code 2", err)
+	}
+}
+
+func TestDBQueryCommandRunsReadOnlyQueries(t *testing.T) {
+	seedDBCommandHome(t)
+
+	output, err := executeRootCommand(
+		"db", "query", "SELECT version FROM schema_migrations ORDER BY version", "--max-rows", "1", "--format", "json",
+	)
+	if err != nil {
+		t.Fatalf("execute db query: %v\noutput:\n%s", err, output)
+	}
+	var result store.QueryResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("decode query result: %v\noutput:\n%s", err, output)
+	}
+	if len(result.Rows) != 1 || !result.Truncated {
+		t.Fatalf("query result = %#v, want one truncated row", result)
+	}
+
+	_, err = executeRootCommand("db", "query", "DELETE FROM schema_migrations")
+	var exitErr *commandExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("execute db query DELETE error = %#v, want exit code 1", err)
+	}
+	if got := loadDBMigrationStatusJSON(t); got.CurrentVersion != globaldb.Migrator().Latest() {
+		t.Fatalf("current version after rejected DELETE = %d, want %d", got.CurrentVersion, globaldb.Migrator().Latest())
+	}
+}
@@ -75,6 +75,7 @@ Use explicit workflow subcommands:
   compozy reviews       Fetch, inspect, and remediate review workflows
   compozy runs          Inspect and clean persisted daemon run artifacts
   compozy sync          Reconcile workflow artifacts into global.db
+  compozy db            Migrate and check the global.db and run.db schemas
   compozy archive       Move fully completed workflows into .compozy/tasks/_archived/
   compozy exec          Execute one ad hoc prompt through the shared ACP runtime`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -94,6 +95,7 @@ Use explicit workflow subcommands:
 		newReviewsCommandWithDefaults(defaults),
 		newRunsCommandWithDefaults(defaults),
 		newSyncCommand(dispatcher),
+		newDBCommand(),
 		newArchiveCommand(dispatcher),
 		newExecCommandWithDefaults(defaults),
 		newMCPServeCommand(),
@@ -43,6 +43,7 @@ func TestRootCommandShowsHelpAndWorkflowSubcommands(t *testing.T) {
 		"compozy reviews",
 		"compozy runs",
 		"compozy sync",
+		"compozy db",
 		"compozy archive",
 		"setup",
 		"agents",
@@ -17,13 +17,14 @@ func newRunsCommandWithDefaults(defaults commandStateDefaults) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:          "runs",
-		Short:        "Inspect, attach, watch, and clean persisted daemon run artifacts",
+		Short:        "Inspect, attach, watch, compare, and clean persisted daemon run artifacts",
 		SilenceUsage: true,
 	}
 
 	cmd.AddCommand(
 		newRunsAttachCommand(defaults),
 		newRunsWatchCommand(),
+		newRunsCompareCommand(),
 		newRunsPurgeCommand(),
 	)
 	return cmd
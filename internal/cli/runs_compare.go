@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/spf13/cobra"
+)
+
+func newRunsCompareCommand() *cobra.Command {
+	var outputFormat string
+	cmd := &cobra.Command{
+		Use:          "compare <run-a> <run-b>",
+		Short:        "Compare two runs of the same workflow job by job",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := normalizeOperatorOutputFormat(outputFormat)
+			if err != nil {
+				return withExitCode(1, err)
+			}
+
+			ctx, stop := signalCommandContext(cmd)
+			defer stop()
+
+			client, err := newCLIDaemonBootstrap().ensure(ctx)
+			if err != nil {
+				return withExitCode(2, err)
+			}
+			comparison, err := client.CompareRuns(ctx, strings.TrimSpace(args[0]), strings.TrimSpace(args[1]))
+			if err != nil {
+				return mapDaemonCommandError(err)
+			}
+			if format == operatorOutputFormatJSON {
+				return writeOperatorJSON(cmd.OutOrStdout(), comparison)
+			}
+			return writeRunComparisonText(cmd.OutOrStdout(), comparison)
+		},
+	}
+	cmd.Flags().StringVar(&outputFormat, "format", operatorOutputFormatText, "Output format: text or json")
+	return cmd
+}
+
+func writeRunComparisonText(out io.Writer, comparison apicore.RunComparison) error {
+	if _, err := fmt.Fprintf(
+		out,
+		"A: %s %s %s\nB: %s %s %s\n\n",
+		comparison.A.Run.RunID,
+		comparison.A.Run.Status,
+		formatRunCompareDuration(comparison.A.DurationMs),
+		comparison.B.Run.RunID,
+		comparison.B.Run.Status,
+		formatRunCompareDuration(comparison.B.DurationMs),
+	); err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(writer, "JOB\tTITLE\tSTATUS A\tSTATUS B\tDURATION Δ\tTOKENS Δ"); err != nil {
+		return err
+	}
+	for _, job := range comparison.Jobs {
+		title := job.Title
+		if title == "" {
+			title = "-"
+		}
+		statusA, statusB := "-", "-"
+		if job.A != nil {
+			statusA = job.A.Status
+		}
+		if job.B != nil {
+			statusB = job.B.Status
+		}
+		durationDelta, tokensDelta := "-", "-"
+		if job.A != nil && job.B != nil {
+			durationDelta = fmt.Sprintf("%+dms", job.DurationDeltaMs)
+			tokensDelta = fmt.Sprintf("%+d", job.TotalTokensDelta)
+		}
+		if _, err := fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			job.Key,
+			title,
+			statusA,
+			statusB,
+			durationDelta,
+			tokensDelta,
+		); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func formatRunCompareDuration(durationMs int64) string {
+	if durationMs <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%dms", durationMs)
+}
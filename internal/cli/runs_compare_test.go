@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/spf13/cobra"
+)
+
+func TestRunsCompareCommandRendersJobDeltas(t *testing.T) {
+	t.Parallel()
+
+	snapshots := map[string]apicore.RunSnapshot{
+		"run-a": {
+			Run: apicore.Run{RunID: "run-a", WorkspaceID: "ws-1", WorkflowSlug: "auth", Mode: "task"},
+			Jobs: []apicore.RunJobState{
+				{JobID: "job-001", TaskID: "task_01", Status: "failed", Summary: &apicore.RunJobSummary{
+					TaskTitle: "Add login", DurationMs: 4000,
+				}},
+			},
+		},
+		"run-b": {
+			Run: apicore.Run{RunID: "run-b", WorkspaceID: "ws-1", WorkflowSlug: "auth", Mode: "task"},
+			Jobs: []apicore.RunJobState{
+				{JobID: "job-007", TaskID: "task_01", Status: "completed", Summary: &apicore.RunJobSummary{
+					TaskTitle: "Add login", DurationMs: 2500,
+				}},
+				{JobID: "job-008", TaskID: "task_02", Status: "completed"},
+			},
+		},
+	}
+	client := &stubDaemonCommandClient{
+		health: apicore.DaemonHealth{Ready: true},
+		snapshotFunc: func(_ context.Context, runID string) (apicore.RunSnapshot, error) {
+			return snapshots[runID], nil
+		},
+	}
+	installTestCLIReadyDaemonBootstrap(t, client)
+	newCommand := func() *cobra.Command { return newRunsCommandWithDefaults(defaultCommandStateDefaults()) }
+
+	output, err := executeCommandCombinedOutput(newCommand(), nil, "compare", "run-a", "run-b")
+	if err != nil {
+		t.Fatalf("execute runs compare: %v\noutput:\n%s", err, output)
+	}
+	for _, want := range []string{"task_01", "Add login", "failed", "-1500ms", "task_02"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("runs compare output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = executeCommandCombinedOutput(newCommand(), nil, "compare", "run-a", "run-b", "--format", "json")
+	if err != nil {
+		t.Fatalf("execute runs compare --format json: %v\noutput:\n%s", err, output)
+	}
+	var payload apicore.RunComparison
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("decode comparison payload: %v\noutput:\n%s", err, output)
+	}
+	if len(payload.Jobs) != 2 || !payload.Jobs[0].StatusChanged || payload.Jobs[1].A != nil {
+		t.Fatalf("unexpected comparison payload: %#v", payload)
+	}
+}
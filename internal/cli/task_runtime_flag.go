@@ -3,7 +3,9 @@ package cli
 import (
 	"encoding/csv"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/compozy/compozy/internal/core/model"
 )
@@ -68,7 +70,8 @@ func parseTaskRuntimeRule(raw string) (model.TaskRuntimeRule, error) {
 	}
 	if !rule.HasOverride() {
 		return model.TaskRuntimeRule{}, fmt.Errorf(
-			"parse --task-runtime: rule must define at least one of ide, model, or reasoning-effort",
+			"parse --task-runtime: rule must define at least one of ide, model, reasoning-effort, timeout, " +
+				"max-retries, or retry-backoff-multiplier",
 		)
 	}
 	return rule, nil
@@ -97,9 +100,28 @@ func applyTaskRuntimeField(rule *model.TaskRuntimeRule, seen map[string]struct{}
 		rule.Model = stringPointer(value)
 	case "reasoning-effort":
 		rule.ReasoningEffort = stringPointer(value)
+	case "timeout":
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("parse --task-runtime: timeout must be a positive duration, got %q", value)
+		}
+		rule.Timeout = stringPointer(value)
+	case "max-retries":
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			return fmt.Errorf("parse --task-runtime: max-retries must be a non-negative integer, got %q", value)
+		}
+		rule.MaxRetries = &retries
+	case "retry-backoff-multiplier":
+		multiplier, err := strconv.ParseFloat(value, 64)
+		if err != nil || multiplier <= 0 {
+			return fmt.Errorf("parse --task-runtime: retry-backoff-multiplier must be positive, got %q", value)
+		}
+		rule.RetryBackoffMultiplier = &multiplier
 	default:
 		return fmt.Errorf(
-			"parse --task-runtime: unknown key %q (expected one of workflow, id, type, ide, model, reasoning-effort)",
+			"parse --task-runtime: unknown key %q (expected one of workflow, id, type, ide, model, "+
+				"reasoning-effort, timeout, max-retries, retry-backoff-multiplier)",
 			key,
 		)
 	}
@@ -121,7 +143,7 @@ func parseTaskRuntimeField(field string) (string, string, error) {
 }
 
 func formatTaskRuntimeRule(rule model.TaskRuntimeRule) string {
-	parts := make([]string, 0, 6)
+	parts := make([]string, 0, 9)
 	if rule.Workflow != nil {
 		parts = append(parts, "workflow="+strings.TrimSpace(*rule.Workflow))
 	}
@@ -140,6 +162,15 @@ func formatTaskRuntimeRule(rule model.TaskRuntimeRule) string {
 	if rule.ReasoningEffort != nil {
 		parts = append(parts, "reasoning-effort="+strings.TrimSpace(*rule.ReasoningEffort))
 	}
+	if rule.Timeout != nil {
+		parts = append(parts, "timeout="+strings.TrimSpace(*rule.Timeout))
+	}
+	if rule.MaxRetries != nil {
+		parts = append(parts, "max-retries="+strconv.Itoa(*rule.MaxRetries))
+	}
+	if rule.RetryBackoffMultiplier != nil {
+		parts = append(parts, "retry-backoff-multiplier="+strconv.FormatFloat(*rule.RetryBackoffMultiplier, 'g', -1, 64))
+	}
 	return strings.Join(parts, ",")
 }
 
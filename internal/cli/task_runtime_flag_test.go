@@ -62,6 +62,28 @@ func TestParseTaskRuntimeRule(t *testing.T) {
 		}
 	})
 
+	t.Run("Should parse and format timeout and retry overrides", func(t *testing.T) {
+		t.Parallel()
+
+		rule, err := parseTaskRuntimeRule("type=migration,timeout=45m,max-retries=3,retry-backoff-multiplier=2.5")
+		if err != nil {
+			t.Fatalf("parseTaskRuntimeRule() error = %v", err)
+		}
+		if rule.Timeout == nil || *rule.Timeout != "45m" {
+			t.Fatalf("unexpected timeout override: %#v", rule.Timeout)
+		}
+		if rule.MaxRetries == nil || *rule.MaxRetries != 3 {
+			t.Fatalf("unexpected max retries override: %#v", rule.MaxRetries)
+		}
+		if rule.RetryBackoffMultiplier == nil || *rule.RetryBackoffMultiplier != 2.5 {
+			t.Fatalf("unexpected backoff override: %#v", rule.RetryBackoffMultiplier)
+		}
+		want := "type=migration,timeout=45m,max-retries=3,retry-backoff-multiplier=2.5"
+		if got := formatTaskRuntimeRule(rule); got != want {
+			t.Fatalf("unexpected formatted rule: %q", got)
+		}
+	})
+
 	for _, tc := range []struct {
 		name    string
 		input   string
@@ -80,7 +102,17 @@ func TestParseTaskRuntimeRule(t *testing.T) {
 		{
 			name:    "rejects missing overrides",
 			input:   "type=frontend",
-			wantErr: "must define at least one of ide, model, or reasoning-effort",
+			wantErr: "must define at least one of ide, model, reasoning-effort",
+		},
+		{
+			name:    "rejects invalid timeouts",
+			input:   "type=frontend,timeout=soon",
+			wantErr: "timeout must be a positive duration",
+		},
+		{
+			name:    "rejects negative max retries",
+			input:   "type=frontend,max-retries=-1",
+			wantErr: "max-retries must be a non-negative integer",
 		},
 		{
 			name:    "rejects unknown keys",
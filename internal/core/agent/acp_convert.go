@@ -516,8 +516,9 @@ func ensureTrailingNewline(text string) string {
 
 // convertACPUsage maps an ACP PromptResponse usage payload to model.Usage.
 //
-// ThoughtTokens (reasoning tokens) are summed into OutputTokens; no dedicated
-// model.Usage field is added in this release (deferred to Phase 3 per ADR-001).
+// ThoughtTokens (reasoning tokens) are summed into OutputTokens so totals stay
+// comparable across agents, and are also reported on their own as
+// ReasoningTokens.
 //
 // TotalTokens is passed through unchanged: the ACP spec defines acp.Usage.TotalTokens
 // as the sum of all token types across the session. Providers may include cache
@@ -525,11 +526,12 @@ func ensureTrailingNewline(text string) string {
 // would undercount real runs.
 func convertACPUsage(u acp.Usage) model.Usage {
 	return model.Usage{
-		InputTokens:  u.InputTokens,
-		OutputTokens: u.OutputTokens + derefInt(u.ThoughtTokens),
-		TotalTokens:  u.TotalTokens,
-		CacheReads:   derefInt(u.CachedReadTokens),
-		CacheWrites:  derefInt(u.CachedWriteTokens),
+		InputTokens:     u.InputTokens,
+		OutputTokens:    u.OutputTokens + derefInt(u.ThoughtTokens),
+		TotalTokens:     u.TotalTokens,
+		CacheReads:      derefInt(u.CachedReadTokens),
+		CacheWrites:     derefInt(u.CachedWriteTokens),
+		ReasoningTokens: derefInt(u.ThoughtTokens),
 	}
 }
 
@@ -30,11 +30,12 @@ func TestConvertACPUsage(t *testing.T) {
 				ThoughtTokens:     acp.Ptr(10),
 			},
 			want: model.Usage{
-				InputTokens:  100,
-				OutputTokens: 60,
-				TotalTokens:  185,
-				CacheReads:   20,
-				CacheWrites:  5,
+				InputTokens:     100,
+				OutputTokens:    60,
+				TotalTokens:     185,
+				CacheReads:      20,
+				CacheWrites:     5,
+				ReasoningTokens: 10,
 			},
 		},
 		{
@@ -74,7 +75,8 @@ func TestConvertACPUsage(t *testing.T) {
 				ThoughtTokens: acp.Ptr(15),
 			},
 			want: model.Usage{
-				OutputTokens: 15,
+				OutputTokens:    15,
+				ReasoningTokens: 15,
 			},
 		},
 	}
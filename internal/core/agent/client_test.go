@@ -2619,7 +2619,7 @@ func TestClientRunPromptPublishesUsageWithThoughtTokensSummedIntoOutput(t *testi
 	}
 
 	// ThoughtTokens(35) must be summed into OutputTokens: 40 + 35 = 75.
-	wantUsage := model.Usage{InputTokens: 200, OutputTokens: 75, TotalTokens: 250}
+	wantUsage := model.Usage{InputTokens: 200, OutputTokens: 75, TotalTokens: 250, ReasoningTokens: 35}
 	var gotUsage bool
 	for _, u := range updates {
 		if u.Usage == wantUsage {
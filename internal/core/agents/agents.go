@@ -34,6 +34,10 @@ const (
 	agentDirName   = "agents"
 	agentFileName  = "AGENT.md"
 	agentMCPConfig = "mcp.json"
+	// fragmentDirName holds shared prompt fragments beside the agent
+	// directories. The leading underscore keeps it out of the slug namespace.
+	fragmentDirName = "_fragments"
+	fragmentFileExt = ".md"
 )
 
 var (
@@ -63,6 +67,8 @@ var (
 	ErrMissingEnvironmentVariable = errors.New("missing environment variable")
 	// ErrReservedMCPServerName indicates that `mcp.json` attempted to declare the host-owned server.
 	ErrReservedMCPServerName = errors.New("reserved MCP server name")
+	// ErrInvalidFragment indicates that `AGENT.md` references a prompt fragment that is invalid or missing.
+	ErrInvalidFragment = errors.New("invalid prompt fragment")
 )
 
 // Scope identifies where an agent definition was discovered.
@@ -224,14 +230,15 @@ func (r *Registry) Discover(ctx context.Context, workspaceRoot string) (Catalog,
 			return Catalog{}, err
 		}
 	}
-	workspaceCandidates, workspaceProblems, err := scanScope(
-		ctx,
-		ScopeWorkspace,
-		filepath.Join(model.CompozyDir(workspaceRoot), agentDirName),
-	)
+	workspaceAgentsRoot := filepath.Join(model.CompozyDir(workspaceRoot), agentDirName)
+	workspaceCandidates, workspaceProblems, err := scanScope(ctx, ScopeWorkspace, workspaceAgentsRoot)
 	if err != nil {
 		return Catalog{}, err
 	}
+	fragments := fragmentRoots{filepath.Join(workspaceAgentsRoot, fragmentDirName)}
+	if globalRoot != "" {
+		fragments = append(fragments, filepath.Join(globalRoot, fragmentDirName))
+	}
 
 	selected := make(map[string]agentCandidate, len(globalCandidates)+len(workspaceCandidates))
 	for name, candidate := range globalCandidates {
@@ -255,7 +262,7 @@ func (r *Registry) Discover(ctx context.Context, workspaceRoot string) (Catalog,
 			return Catalog{}, fmt.Errorf("discover agents: %w", err)
 		}
 
-		resolved, loadErr := r.loadAgent(selected[name])
+		resolved, loadErr := r.loadAgent(selected[name], fragments)
 		if loadErr != nil {
 			catalog.Problems = append(catalog.Problems, Problem{
 				Name:   name,
@@ -294,13 +301,18 @@ type agentCandidate struct {
 	Source Source
 }
 
+// fragmentRoots lists the `_fragments` directories searched in order, so a
+// workspace fragment shadows a global fragment with the same name.
+type fragmentRoots []string
+
 type frontmatterFields struct {
-	Title           string `yaml:"title"`
-	Description     string `yaml:"description"`
-	IDE             string `yaml:"ide"`
-	Model           string `yaml:"model"`
-	ReasoningEffort string `yaml:"reasoning_effort"`
-	AccessMode      string `yaml:"access_mode"`
+	Title           string   `yaml:"title"`
+	Description     string   `yaml:"description"`
+	IDE             string   `yaml:"ide"`
+	Model           string   `yaml:"model"`
+	ReasoningEffort string   `yaml:"reasoning_effort"`
+	AccessMode      string   `yaml:"access_mode"`
+	Fragments       []string `yaml:"fragments"`
 }
 
 type rawMCPServer struct {
@@ -328,7 +340,7 @@ func scanScope(
 		if err := context.Cause(ctx); err != nil {
 			return nil, nil, fmt.Errorf("discover agents: %w", err)
 		}
-		if !entry.IsDir() {
+		if !entry.IsDir() || entry.Name() == fragmentDirName {
 			continue
 		}
 
@@ -350,7 +362,7 @@ func scanScope(
 	return candidates, problems, nil
 }
 
-func (r *Registry) loadAgent(candidate agentCandidate) (ResolvedAgent, error) {
+func (r *Registry) loadAgent(candidate agentCandidate, fragments fragmentRoots) (ResolvedAgent, error) {
 	content, err := os.ReadFile(candidate.Source.DefinitionPath)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -359,7 +371,11 @@ func (r *Registry) loadAgent(candidate agentCandidate) (ResolvedAgent, error) {
 		return ResolvedAgent{}, fmt.Errorf("read %s: %w", candidate.Source.DefinitionPath, err)
 	}
 
-	metadata, runtime, prompt, err := parseAgentDefinition(candidate.Source.DefinitionPath, string(content))
+	definition, err := parseAgentDefinition(candidate.Source.DefinitionPath, string(content))
+	if err != nil {
+		return ResolvedAgent{}, err
+	}
+	prompt, err := composeAgentPrompt(candidate.Source.DefinitionPath, definition, fragments)
 	if err != nil {
 		return ResolvedAgent{}, err
 	}
@@ -371,27 +387,35 @@ func (r *Registry) loadAgent(candidate agentCandidate) (ResolvedAgent, error) {
 
 	return ResolvedAgent{
 		Name:     candidate.Name,
-		Metadata: metadata,
-		Runtime:  runtime,
+		Metadata: definition.Metadata,
+		Runtime:  definition.Runtime,
 		Prompt:   prompt,
 		Source:   candidate.Source,
 		MCP:      mcpConfig,
 	}, nil
 }
 
-func parseAgentDefinition(path string, content string) (Metadata, RuntimeDefaults, string, error) {
+// agentDefinition is the parsed form of one `AGENT.md` before fragments are composed.
+type agentDefinition struct {
+	Metadata  Metadata
+	Runtime   RuntimeDefaults
+	Fragments []string
+	Body      string
+}
+
+func parseAgentDefinition(path string, content string) (agentDefinition, error) {
 	rawFields := map[string]any{}
 	if _, err := frontmatter.Parse(content, &rawFields); err != nil {
-		return Metadata{}, RuntimeDefaults{}, "", fmt.Errorf("%w: %s: %v", ErrMalformedFrontmatter, path, err)
+		return agentDefinition{}, fmt.Errorf("%w: %s: %v", ErrMalformedFrontmatter, path, err)
 	}
 	if err := validateUnsupportedFields(path, rawFields); err != nil {
-		return Metadata{}, RuntimeDefaults{}, "", err
+		return agentDefinition{}, err
 	}
 
 	var parsed frontmatterFields
 	body, err := frontmatter.Parse(content, &parsed)
 	if err != nil {
-		return Metadata{}, RuntimeDefaults{}, "", fmt.Errorf("%w: %s: %v", ErrMalformedFrontmatter, path, err)
+		return agentDefinition{}, fmt.Errorf("%w: %s: %v", ErrMalformedFrontmatter, path, err)
 	}
 
 	metadata := Metadata{
@@ -405,12 +429,12 @@ func parseAgentDefinition(path string, content string) (Metadata, RuntimeDefault
 		AccessMode:      strings.TrimSpace(parsed.AccessMode),
 	}
 	if err := validateRuntimeDefaults(path, runtime); err != nil {
-		return Metadata{}, RuntimeDefaults{}, "", err
+		return agentDefinition{}, err
 	}
 	if strings.TrimSpace(runtime.IDE) != "" && strings.TrimSpace(runtime.Model) == "" {
 		modelName, err := runtimeagent.ResolveRuntimeModel(runtime.IDE, "")
 		if err != nil {
-			return Metadata{}, RuntimeDefaults{}, "", fmt.Errorf(
+			return agentDefinition{}, fmt.Errorf(
 				"%w: %s ide %q is not supported",
 				ErrInvalidRuntimeDefaults,
 				path,
@@ -420,7 +444,62 @@ func parseAgentDefinition(path string, content string) (Metadata, RuntimeDefault
 		runtime.Model = strings.TrimSpace(modelName)
 	}
 
-	return metadata, runtime, body, nil
+	return agentDefinition{
+		Metadata:  metadata,
+		Runtime:   runtime,
+		Fragments: parsed.Fragments,
+		Body:      body,
+	}, nil
+}
+
+// composeAgentPrompt prepends the fragments listed in the `fragments`
+// frontmatter field, in order, to the agent's own prompt body.
+func composeAgentPrompt(path string, definition agentDefinition, roots fragmentRoots) (string, error) {
+	if len(definition.Fragments) == 0 {
+		return definition.Body, nil
+	}
+
+	parts := make([]string, 0, len(definition.Fragments)+1)
+	seen := make(map[string]struct{}, len(definition.Fragments))
+	for _, rawName := range definition.Fragments {
+		name := strings.TrimSpace(rawName)
+		if !slugPattern.MatchString(name) {
+			return "", fmt.Errorf("%w: %s fragment %q must match %s", ErrInvalidFragment, path, name, slugPattern.String())
+		}
+		if _, dup := seen[name]; dup {
+			return "", fmt.Errorf("%w: %s lists fragment %q more than once", ErrInvalidFragment, path, name)
+		}
+		seen[name] = struct{}{}
+
+		text, err := roots.load(name)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", ErrInvalidFragment, path, err)
+		}
+		parts = append(parts, text)
+	}
+	if trimmed := strings.TrimSpace(definition.Body); trimmed != "" {
+		parts = append(parts, trimmed)
+	}
+	return strings.Join(parts, "\n\n") + "\n", nil
+}
+
+func (roots fragmentRoots) load(name string) (string, error) {
+	for _, root := range roots {
+		path := filepath.Join(root, name+fragmentFileExt)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return "", fmt.Errorf("read fragment %s: %w", path, err)
+		}
+		text := strings.TrimSpace(string(content))
+		if text == "" {
+			return "", fmt.Errorf("fragment %s is empty", path)
+		}
+		return text, nil
+	}
+	return "", fmt.Errorf("fragment %q not found in %s", name, strings.Join(roots, ", "))
 }
 
 func validateUnsupportedFields(path string, rawFields map[string]any) error {
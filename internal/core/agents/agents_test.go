@@ -527,6 +527,68 @@ func TestDiscoverSurfacesMalformedAgentWithoutCorruptingValidOnes(t *testing.T)
 	}
 }
 
+func TestDiscoverComposesPromptFragments(t *testing.T) {
+	t.Parallel()
+
+	homeDir := t.TempDir()
+	workspaceRoot := t.TempDir()
+	writeGlobalFragment(t, homeDir, "base-policy", "Global policy.")
+	writeGlobalFragment(t, homeDir, "output-conventions", "Answer in markdown.\n")
+	writeWorkspaceFragment(t, workspaceRoot, "base-policy", "Workspace policy.")
+	writeWorkspaceAgent(t, workspaceRoot, "reviewer", agentMarkdownWithFragments(
+		"Review the diff.",
+		"base-policy",
+		"output-conventions",
+	), "")
+	writeWorkspaceAgent(t, workspaceRoot, "missing", agentMarkdownWithFragments("Plan.", "no-such-fragment"), "")
+	writeWorkspaceAgent(t, workspaceRoot, "twice", agentMarkdownWithFragments("Plan.", "base-policy", "base-policy"), "")
+
+	registry := newTestRegistry(homeDir, nil)
+	catalog, err := registry.Discover(context.Background(), workspaceRoot)
+	if err != nil {
+		t.Fatalf("discover agents: %v", err)
+	}
+
+	t.Run("Should prepend fragments in order and prefer workspace fragments", func(t *testing.T) {
+		t.Parallel()
+
+		resolved, err := catalog.Resolve("reviewer")
+		if err != nil {
+			t.Fatalf("resolve reviewer: %v", err)
+		}
+		want := "Workspace policy.\n\nAnswer in markdown.\n\nReview the diff.\n"
+		if resolved.Prompt != want {
+			t.Fatalf("unexpected composed prompt\nwant: %q\ngot:  %q", want, resolved.Prompt)
+		}
+	})
+
+	t.Run("Should not treat the fragments directory as an agent", func(t *testing.T) {
+		t.Parallel()
+
+		if _, found := problemsByName(catalog.Problems)[fragmentDirName]; found {
+			t.Fatalf("expected %s to be skipped during discovery, got %#v", fragmentDirName, catalog.Problems)
+		}
+	})
+
+	t.Run("Should report missing and repeated fragments as invalid agents", func(t *testing.T) {
+		t.Parallel()
+
+		problems := problemsByName(catalog.Problems)
+		for _, name := range []string{"missing", "twice"} {
+			problem, found := problems[name]
+			if !found {
+				t.Fatalf("expected a problem for %q, got %#v", name, catalog.Problems)
+			}
+			if !errors.Is(problem.Err, ErrInvalidFragment) {
+				t.Fatalf("expected invalid fragment error for %q, got %v", name, problem.Err)
+			}
+			if reason, ok := BlockedReasonForError(problem.Err); !ok || reason == "" {
+				t.Fatalf("expected %q to classify as a blocked reason, got %q", name, reason)
+			}
+		}
+	})
+}
+
 func newTestRegistry(homeDir string, env map[string]string) *Registry {
 	return New(
 		WithHomeDir(func() (string, error) {
@@ -582,6 +644,36 @@ func writeAgentFiles(t *testing.T, root, name, agentContent, mcpContent string)
 	return agentDir
 }
 
+func writeWorkspaceFragment(t *testing.T, workspaceRoot, name, content string) {
+	t.Helper()
+	writeFragmentFile(t, filepath.Join(workspaceRoot, model.WorkflowRootDirName, agentDirName), name, content)
+}
+
+func writeGlobalFragment(t *testing.T, homeDir, name, content string) {
+	t.Helper()
+	writeFragmentFile(t, filepath.Join(homeDir, model.WorkflowRootDirName, agentDirName), name, content)
+}
+
+func writeFragmentFile(t *testing.T, root, name, content string) {
+	t.Helper()
+
+	dir := filepath.Join(root, fragmentDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir fragment dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+fragmentFileExt), []byte(content), 0o644); err != nil {
+		t.Fatalf("write fragment %s: %v", name, err)
+	}
+}
+
+func agentMarkdownWithFragments(prompt string, fragments ...string) string {
+	lines := []string{"---", "title: Fragment Agent", "description: Test agent", "fragments:"}
+	for _, fragment := range fragments {
+		lines = append(lines, "  - "+fragment)
+	}
+	return strings.Join(append(lines, "---", "", prompt, ""), "\n")
+}
+
 func validAgentMarkdown(title, prompt string) string {
 	return strings.Join([]string{
 		"---",
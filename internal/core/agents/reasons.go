@@ -19,7 +19,8 @@ func BlockedReasonForError(err error) (kinds.ReusableAgentBlockedReason, bool) {
 		errors.Is(err, ErrMissingAgentDefinition),
 		errors.Is(err, ErrMalformedFrontmatter),
 		errors.Is(err, ErrUnsupportedMetadataField),
-		errors.Is(err, ErrInvalidRuntimeDefaults):
+		errors.Is(err, ErrInvalidRuntimeDefaults),
+		errors.Is(err, ErrInvalidFragment):
 		return kinds.ReusableAgentBlockedReasonInvalidAgent, true
 	case errors.Is(err, ErrMalformedMCPConfig),
 		errors.Is(err, ErrMissingEnvironmentVariable),
@@ -10,21 +10,23 @@ import (
 
 func PublicUsage(usage model.Usage) kinds.Usage {
 	return kinds.Usage{
-		InputTokens:  usage.InputTokens,
-		OutputTokens: usage.OutputTokens,
-		TotalTokens:  usage.TotalTokens,
-		CacheReads:   usage.CacheReads,
-		CacheWrites:  usage.CacheWrites,
+		InputTokens:     usage.InputTokens,
+		OutputTokens:    usage.OutputTokens,
+		TotalTokens:     usage.TotalTokens,
+		CacheReads:      usage.CacheReads,
+		CacheWrites:     usage.CacheWrites,
+		ReasoningTokens: usage.ReasoningTokens,
 	}
 }
 
 func InternalUsage(usage kinds.Usage) model.Usage {
 	return model.Usage{
-		InputTokens:  usage.InputTokens,
-		OutputTokens: usage.OutputTokens,
-		TotalTokens:  usage.TotalTokens,
-		CacheReads:   usage.CacheReads,
-		CacheWrites:  usage.CacheWrites,
+		InputTokens:     usage.InputTokens,
+		OutputTokens:    usage.OutputTokens,
+		TotalTokens:     usage.TotalTokens,
+		CacheReads:      usage.CacheReads,
+		CacheWrites:     usage.CacheWrites,
+		ReasoningTokens: usage.ReasoningTokens,
 	}
 }
 
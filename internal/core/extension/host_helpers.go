@@ -497,6 +497,15 @@ func (o *defaultKernelOps) PublishEvent(
 			"error":  "kind is required",
 		})
 	}
+	if payload.Kind == kinds.ExtensionMetricEventKind {
+		if _, err := ParseMetricPayload(payload.Payload); err != nil {
+			return nil, subprocess.NewInvalidParams(map[string]any{
+				"method": "host.events.publish",
+				"field":  "payload",
+				"error":  err.Error(),
+			})
+		}
+	}
 
 	seq, err := o.submitRuntimeEvent(ctx, events.EventKindExtensionEvent, payload)
 	if err != nil {
@@ -441,6 +441,41 @@ func TestHostEventsPublishRejectsMissingKind(t *testing.T) {
 	assertRequestErrorCode(t, err, -32602)
 }
 
+func TestHostEventsPublishValidatesMetricPayloads(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		payload string
+		wantErr bool
+	}{
+		{name: "Should accept a counter with labels", payload: `{"name":"documents_processed","value":3,"labels":{"source":"s3"}}`},
+		{name: "Should accept a negative gauge", payload: `{"name":"queue_delta","type":"gauge","value":-2}`},
+		{name: "Should reject an invalid name", payload: `{"name":"docs-processed","value":1}`, wantErr: true},
+		{name: "Should reject a negative counter", payload: `{"name":"leads_scored","value":-1}`, wantErr: true},
+		{name: "Should reject an unknown type", payload: `{"name":"latency","type":"histogram","value":1}`, wantErr: true},
+		{name: "Should reject a reserved label", payload: `{"name":"docs","value":1,"labels":{"__name__":"x"}}`, wantErr: true},
+		{name: "Should reject unknown fields", payload: `{"name":"docs","value":1,"unit":"files"}`, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := newHostRuntime(t, []Capability{CapabilityEventsPublish}, nil, "")
+			_, err := rt.router.Handle(context.Background(), "ext", "host.events.publish", mustJSON(t, EventPublishRequest{
+				Kind:    kinds.ExtensionMetricEventKind,
+				Payload: json.RawMessage(tc.payload),
+			}))
+			if tc.wantErr {
+				assertRequestErrorCode(t, err, -32602)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+		})
+	}
+}
+
 func TestRuntimeExtensionEventFiltersAndNameFallbacks(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,62 @@
+package extensions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/compozy/compozy/pkg/compozy/events/kinds"
+)
+
+const maxMetricLabels = 16
+
+var metricIdentifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ParseMetricPayload decodes and validates the payload of a custom metric
+// extension event. Names and label keys follow Prometheus identifier rules,
+// the type defaults to counter, and counters reject negative increments.
+func ParseMetricPayload(raw json.RawMessage) (kinds.ExtensionMetricPayload, error) {
+	var metric kinds.ExtensionMetricPayload
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&metric); err != nil {
+		return kinds.ExtensionMetricPayload{}, fmt.Errorf("decode metric payload: %w", err)
+	}
+
+	metric.Name = strings.TrimSpace(metric.Name)
+	if !metricIdentifierPattern.MatchString(metric.Name) {
+		return kinds.ExtensionMetricPayload{}, fmt.Errorf("metric name %q is invalid", metric.Name)
+	}
+	metric.Type = strings.ToLower(strings.TrimSpace(metric.Type))
+	switch metric.Type {
+	case "":
+		metric.Type = kinds.MetricTypeCounter
+	case kinds.MetricTypeCounter, kinds.MetricTypeGauge:
+	default:
+		return kinds.ExtensionMetricPayload{}, fmt.Errorf("metric type %q must be counter or gauge", metric.Type)
+	}
+	if math.IsNaN(metric.Value) || math.IsInf(metric.Value, 0) {
+		return kinds.ExtensionMetricPayload{}, fmt.Errorf("metric %q value must be finite", metric.Name)
+	}
+	if metric.Type == kinds.MetricTypeCounter && metric.Value < 0 {
+		return kinds.ExtensionMetricPayload{}, fmt.Errorf("counter %q cannot decrease", metric.Name)
+	}
+	if len(metric.Labels) > maxMetricLabels {
+		return kinds.ExtensionMetricPayload{}, fmt.Errorf(
+			"metric %q has %d labels; at most %d are allowed",
+			metric.Name,
+			len(metric.Labels),
+			maxMetricLabels,
+		)
+	}
+	for key := range metric.Labels {
+		if !metricIdentifierPattern.MatchString(key) || strings.HasPrefix(key, "__") {
+			return kinds.ExtensionMetricPayload{}, fmt.Errorf("metric %q label %q is invalid", metric.Name, key)
+		}
+	}
+	metric.Help = strings.TrimSpace(metric.Help)
+	return metric, nil
+}
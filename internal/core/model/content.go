@@ -172,6 +172,8 @@ type Usage struct {
 	TotalTokens  int `json:"totalTokens,omitempty"`
 	CacheReads   int `json:"cacheReads,omitempty"`
 	CacheWrites  int `json:"cacheWrites,omitempty"`
+	// ReasoningTokens is the share of OutputTokens spent on reasoning.
+	ReasoningTokens int `json:"reasoningTokens,omitempty"`
 }
 
 // Add accumulates usage from another update into the receiver.
@@ -181,6 +183,7 @@ func (u *Usage) Add(other Usage) {
 	u.TotalTokens += other.TotalTokens
 	u.CacheReads += other.CacheReads
 	u.CacheWrites += other.CacheWrites
+	u.ReasoningTokens += other.ReasoningTokens
 }
 
 // Total returns the derived total token count when TotalTokens is not populated.
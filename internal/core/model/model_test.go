@@ -692,6 +692,37 @@ func TestRuntimeConfigRuntimeForTask(t *testing.T) {
 			t.Fatalf("blank workflow qualifier should match any workflow, got %#v", resolved)
 		}
 	})
+	t.Run("Should apply timeout and retry overrides from matching rules", func(t *testing.T) {
+		t.Parallel()
+
+		maxRetries := 4
+		multiplier := 2.0
+		cfg := &model.RuntimeConfig{
+			IDE:                    model.IDECodex,
+			Timeout:                time.Minute,
+			MaxRetries:             1,
+			RetryBackoffMultiplier: 1.5,
+			TaskRuntimeRules: []model.TaskRuntimeRule{{
+				Type:                   testStringPointer("migration"),
+				Timeout:                testStringPointer("45m"),
+				MaxRetries:             &maxRetries,
+				RetryBackoffMultiplier: &multiplier,
+			}},
+		}
+
+		resolved := cfg.RuntimeForTask(model.TaskRuntimeTarget{ID: "task_01", Type: "migration"})
+		if resolved.Timeout != 45*time.Minute || resolved.MaxRetries != 4 || resolved.RetryBackoffMultiplier != 2 {
+			t.Fatalf("unexpected retry runtime: %#v", resolved)
+		}
+		if resolved.IDE != model.IDECodex {
+			t.Fatalf("retry-only rule changed ide: %q", resolved.IDE)
+		}
+
+		other := cfg.RuntimeForTask(model.TaskRuntimeTarget{ID: "task_02", Type: "backend"})
+		if other.Timeout != time.Minute || other.MaxRetries != 1 || other.RetryBackoffMultiplier != 1.5 {
+			t.Fatalf("unexpected base retry runtime: %#v", other)
+		}
+	})
 }
 
 func TestRuntimeConfigClonePreservesTargetTaskNumber(t *testing.T) {
@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/compozy/compozy/internal/core/run/journal"
 	"github.com/compozy/compozy/pkg/compozy/events"
@@ -82,12 +83,22 @@ type Job struct {
 	IDE             string
 	Model           string
 	ReasoningEffort string
-	Prompt          []byte
-	SystemPrompt    string
-	MCPServers      []MCPServer
-	OutPromptPath   string
-	OutLog          string
-	ErrLog          string
+	// RetryPolicy is set when task runtime rules give this job its own attempt
+	// timeout or retry budget; nil jobs follow the run configuration.
+	RetryPolicy   *JobRetryPolicy
+	Prompt        []byte
+	SystemPrompt  string
+	MCPServers    []MCPServer
+	OutPromptPath string
+	OutLog        string
+	ErrLog        string
+}
+
+// JobRetryPolicy is the attempt timeout and retry budget of one job.
+type JobRetryPolicy struct {
+	Timeout                time.Duration
+	MaxRetries             int
+	RetryBackoffMultiplier float64
 }
 
 func (j Job) IssueCount() int {
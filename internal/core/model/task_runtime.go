@@ -1,18 +1,24 @@
 package model
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // TaskRuntimeRule defines runtime overrides that apply to one task selector.
 // Exactly one selector should be set in validated inputs. Workflow is an
 // optional qualifier that scopes a rule without changing selector specificity.
 type TaskRuntimeRule struct {
-	Workflow        *string `toml:"workflow"         json:"workflow,omitempty"`
-	ID              *string `toml:"id"               json:"id,omitempty"`
-	Type            *string `toml:"type"             json:"type,omitempty"`
-	Complexity      *string `toml:"complexity"       json:"complexity,omitempty"`
-	IDE             *string `toml:"ide"              json:"ide,omitempty"`
-	Model           *string `toml:"model"            json:"model,omitempty"`
-	ReasoningEffort *string `toml:"reasoning_effort" json:"reasoning_effort,omitempty"`
+	Workflow               *string  `toml:"workflow"                 json:"workflow,omitempty"`
+	ID                     *string  `toml:"id"                       json:"id,omitempty"`
+	Type                   *string  `toml:"type"                     json:"type,omitempty"`
+	Complexity             *string  `toml:"complexity"               json:"complexity,omitempty"`
+	IDE                    *string  `toml:"ide"                      json:"ide,omitempty"`
+	Model                  *string  `toml:"model"                    json:"model,omitempty"`
+	ReasoningEffort        *string  `toml:"reasoning_effort"         json:"reasoning_effort,omitempty"`
+	Timeout                *string  `toml:"timeout"                  json:"timeout,omitempty"`
+	MaxRetries             *int     `toml:"max_retries"              json:"max_retries,omitempty"`
+	RetryBackoffMultiplier *float64 `toml:"retry_backoff_multiplier" json:"retry_backoff_multiplier,omitempty"`
 }
 
 // TaskRuntimeTarget identifies the task being resolved against runtime rules.
@@ -55,13 +61,16 @@ func CloneTaskRuntimeRules(src []TaskRuntimeRule) []TaskRuntimeRule {
 
 func (r TaskRuntimeRule) clone() TaskRuntimeRule {
 	return TaskRuntimeRule{
-		Workflow:        cloneTrimmedOptionalString(r.Workflow),
-		ID:              cloneTrimmedOptionalString(r.ID),
-		Type:            cloneTrimmedOptionalString(r.Type),
-		Complexity:      cloneTrimmedOptionalString(r.Complexity),
-		IDE:             cloneTrimmedOptionalString(r.IDE),
-		Model:           cloneTrimmedOptionalString(r.Model),
-		ReasoningEffort: cloneTrimmedOptionalString(r.ReasoningEffort),
+		Workflow:               cloneTrimmedOptionalString(r.Workflow),
+		ID:                     cloneTrimmedOptionalString(r.ID),
+		Type:                   cloneTrimmedOptionalString(r.Type),
+		Complexity:             cloneTrimmedOptionalString(r.Complexity),
+		IDE:                    cloneTrimmedOptionalString(r.IDE),
+		Model:                  cloneTrimmedOptionalString(r.Model),
+		ReasoningEffort:        cloneTrimmedOptionalString(r.ReasoningEffort),
+		Timeout:                cloneTrimmedOptionalString(r.Timeout),
+		MaxRetries:             cloneOptionalValue(r.MaxRetries),
+		RetryBackoffMultiplier: cloneOptionalValue(r.RetryBackoffMultiplier),
 	}
 }
 
@@ -70,7 +79,13 @@ func (r TaskRuntimeRule) HasSelector() bool {
 }
 
 func (r TaskRuntimeRule) HasOverride() bool {
-	return r.IDE != nil || r.Model != nil || r.ReasoningEffort != nil
+	return r.IDE != nil || r.Model != nil || r.ReasoningEffort != nil || r.HasRetryOverride()
+}
+
+// HasRetryOverride reports whether the rule changes the attempt timeout or the
+// retry budget of matching tasks.
+func (r TaskRuntimeRule) HasRetryOverride() bool {
+	return r.Timeout != nil || r.MaxRetries != nil || r.RetryBackoffMultiplier != nil
 }
 
 func (r TaskRuntimeRule) IsIDRule() bool {
@@ -173,6 +188,7 @@ func applyTaskComplexityRuntimeRule(cfg *RuntimeConfig, rule TaskRuntimeRule) {
 	if rule.ReasoningEffort != nil && !cfg.ExplicitRuntime.ReasoningEffort {
 		cfg.ReasoningEffort = strings.TrimSpace(*rule.ReasoningEffort)
 	}
+	applyTaskRetryRuntimeRule(cfg, rule)
 }
 
 func applyTaskRuntimeRule(cfg *RuntimeConfig, rule TaskRuntimeRule) {
@@ -188,6 +204,24 @@ func applyTaskRuntimeRule(cfg *RuntimeConfig, rule TaskRuntimeRule) {
 	if rule.ReasoningEffort != nil {
 		cfg.ReasoningEffort = strings.TrimSpace(*rule.ReasoningEffort)
 	}
+	applyTaskRetryRuntimeRule(cfg, rule)
+}
+
+// applyTaskRetryRuntimeRule copies the attempt timeout and retry budget of a
+// matching rule. Timeouts are validated where rules enter the config, so an
+// unparsable value here leaves the inherited timeout in place.
+func applyTaskRetryRuntimeRule(cfg *RuntimeConfig, rule TaskRuntimeRule) {
+	if rule.Timeout != nil {
+		if timeout, err := time.ParseDuration(strings.TrimSpace(*rule.Timeout)); err == nil && timeout > 0 {
+			cfg.Timeout = timeout
+		}
+	}
+	if rule.MaxRetries != nil {
+		cfg.MaxRetries = *rule.MaxRetries
+	}
+	if rule.RetryBackoffMultiplier != nil {
+		cfg.RetryBackoffMultiplier = *rule.RetryBackoffMultiplier
+	}
 }
 
 // TaskRuntimeFromConfig returns the task-scoped runtime view for cfg.
@@ -219,3 +253,11 @@ func cloneTrimmedOptionalString(value *string) *string {
 	cloned := strings.TrimSpace(*value)
 	return &cloned
 }
+
+func cloneOptionalValue[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	cloned := *value
+	return &cloned
+}
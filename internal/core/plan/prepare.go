@@ -507,6 +507,7 @@ func buildBatchJob(
 		IDE:             jobRuntime.IDE,
 		Model:           jobRuntime.Model,
 		ReasoningEffort: jobRuntime.ReasoningEffort,
+		RetryPolicy:     resolveJobRetryPolicy(cfg, jobRuntime),
 		Prompt:          []byte(generated.promptText),
 		SystemPrompt:    generated.systemPrompt,
 		MCPServers:      generated.mcpServers,
@@ -606,6 +607,27 @@ func resolveBatchJobRuntime(
 	return jobRuntime, nil
 }
 
+// resolveJobRetryPolicy returns the job's own attempt timeout and retry budget
+// when task runtime rules changed them. Jobs without a policy keep following
+// the run configuration, including changes made by run.pre_start hooks.
+func resolveJobRetryPolicy(cfg *model.RuntimeConfig, jobRuntime *model.RuntimeConfig) *model.JobRetryPolicy {
+	if cfg == nil || jobRuntime == nil {
+		return nil
+	}
+	base := cfg.Clone()
+	base.ApplyDefaults()
+	if jobRuntime.Timeout == base.Timeout &&
+		jobRuntime.MaxRetries == base.MaxRetries &&
+		jobRuntime.RetryBackoffMultiplier == base.RetryBackoffMultiplier {
+		return nil
+	}
+	return &model.JobRetryPolicy{
+		Timeout:                jobRuntime.Timeout,
+		MaxRetries:             jobRuntime.MaxRetries,
+		RetryBackoffMultiplier: jobRuntime.RetryBackoffMultiplier,
+	}
+}
+
 func dispatchPlanPreResolveTaskRuntime(
 	ctx context.Context,
 	manager model.RuntimeManager,
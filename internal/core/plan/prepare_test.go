@@ -487,6 +487,7 @@ func TestPrepareJobsResolvesPerTaskRuntimeOverrides(t *testing.T) {
 		t.Fatalf("mkdir jobs dir: %v", err)
 	}
 	tasksDir := t.TempDir()
+	taskMaxRetries := 3
 	groups := map[string][]model.IssueEntry{
 		"task_01": {{
 			Name:     "task_01.md",
@@ -534,8 +535,10 @@ func TestPrepareJobsResolvesPerTaskRuntimeOverrides(t *testing.T) {
 				IDE:      testStringPointer(model.IDEClaude),
 			},
 			{
-				ID:    testStringPointer("task_02"),
-				Model: testStringPointer("codex-fast"),
+				ID:         testStringPointer("task_02"),
+				Model:      testStringPointer("codex-fast"),
+				Timeout:    testStringPointer("45m"),
+				MaxRetries: &taskMaxRetries,
 			},
 		},
 	}, groups, runArtifacts, nil, nil)
@@ -551,6 +554,12 @@ func TestPrepareJobsResolvesPerTaskRuntimeOverrides(t *testing.T) {
 	if jobs[1].IDE != model.IDEClaude || jobs[1].Model != "codex-fast" || jobs[1].ReasoningEffort != "low" {
 		t.Fatalf("unexpected backend runtime: %#v", jobs[1])
 	}
+	if jobs[0].RetryPolicy != nil {
+		t.Fatalf("expected frontend job to follow the run retry policy, got %#v", jobs[0].RetryPolicy)
+	}
+	if policy := jobs[1].RetryPolicy; policy == nil || policy.Timeout != 45*time.Minute || policy.MaxRetries != 3 {
+		t.Fatalf("unexpected backend retry policy: %#v", policy)
+	}
 }
 
 func TestPrepareJobsRejectsPerTaskRuntimeThatCannotReuseGlobalAddDirs(t *testing.T) {
@@ -850,6 +850,9 @@ func printAggregateUsage(usage *model.Usage) {
 		fmt.Printf("  Cache Writes:          %s\n", formatNumber(usage.CacheWrites))
 	}
 	fmt.Printf("  Output Tokens:         %s\n", formatNumber(usage.OutputTokens))
+	if usage.ReasoningTokens > 0 {
+		fmt.Printf("  Reasoning Tokens:      %s\n", formatNumber(usage.ReasoningTokens))
+	}
 	fmt.Println(strings.Repeat("-", 60))
 	fmt.Printf("  Total Tokens:          %s\n", formatNumber(usage.Total()))
 	fmt.Println(strings.Repeat("=", 60))
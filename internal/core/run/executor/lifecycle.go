@@ -316,7 +316,21 @@ func (l *jobLifecycle) maxAttempts() int {
 	if cfg == nil {
 		return 1
 	}
-	return atLeastOne(cfg.MaxRetries + 1)
+	return atLeastOne(jobRetryPolicy(l.job, cfg).MaxRetries + 1)
+}
+
+func jobRetryPolicy(jb *job, cfg *config) model.JobRetryPolicy {
+	if jb != nil && jb.RetryPolicy != nil {
+		return *jb.RetryPolicy
+	}
+	if cfg == nil {
+		return model.JobRetryPolicy{}
+	}
+	return model.JobRetryPolicy{
+		Timeout:                cfg.Timeout,
+		MaxRetries:             cfg.MaxRetries,
+		RetryBackoffMultiplier: cfg.RetryBackoffMultiplier,
+	}
 }
 
 func (l *jobLifecycle) humanOutputEnabled() bool {
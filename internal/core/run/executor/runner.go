@@ -84,12 +84,13 @@ func (r *jobRunner) run(ctx context.Context) {
 // to stop, so a stall retry can run past the ordinary MaxRetries ceiling.
 func (r *jobRunner) executeAttempts(ctx context.Context) {
 	r.preSnapshot = r.captureWorkspaceSnapshot(ctx)
+	policy := r.retryPolicy()
 	budget := attemptBudget{
-		ordinary: r.execCtx.cfg.MaxRetries,
+		ordinary: policy.MaxRetries,
 		stall:    r.stallRetries(),
-		total:    atLeastOne(r.execCtx.cfg.MaxRetries + 1),
+		total:    atLeastOne(policy.MaxRetries + 1),
 	}
-	timeout := r.execCtx.cfg.Timeout
+	timeout := policy.Timeout
 	for attempt := 1; ; attempt++ {
 		if ctx.Err() != nil {
 			r.lifecycle.markCanceled(exitCodeCanceled)
@@ -124,6 +125,12 @@ func (r *jobRunner) executeAttempts(ctx context.Context) {
 	}
 }
 
+// retryPolicy is the job's own attempt timeout and retry budget when task
+// runtime rules set one, and the run configuration's otherwise.
+func (r *jobRunner) retryPolicy() model.JobRetryPolicy {
+	return jobRetryPolicy(r.job, r.execCtx.cfg)
+}
+
 // stallRetries is the one-shot recovery budget for a frozen agent, read from the
 // resolved stall policy rather than MaxRetries.
 func (r *jobRunner) stallRetries() int {
@@ -368,7 +375,7 @@ func (r *jobRunner) nextTimeout(current time.Duration) time.Duration {
 	if current <= 0 {
 		return current
 	}
-	next := time.Duration(float64(current) * r.execCtx.cfg.RetryBackoffMultiplier)
+	next := time.Duration(float64(current) * r.retryPolicy().RetryBackoffMultiplier)
 	const maxTimeout = 30 * time.Minute
 	if next > maxTimeout {
 		return maxTimeout
@@ -452,7 +452,8 @@ func hasUsage(usage model.Usage) bool {
 		usage.OutputTokens != 0 ||
 		usage.TotalTokens != 0 ||
 		usage.CacheReads != 0 ||
-		usage.CacheWrites != 0
+		usage.CacheWrites != 0 ||
+		usage.ReasoningTokens != 0
 }
 
 func sessionHandlerUsage(usage *model.Usage) model.Usage {
@@ -59,6 +59,7 @@ type Job struct {
 	IDE             string
 	Model           string
 	ReasoningEffort string
+	RetryPolicy     *model.JobRetryPolicy
 	ReusableAgent   *ReusableAgentExecution
 	Prompt          []byte
 	SystemPrompt    string
@@ -174,6 +175,7 @@ func NewJobs(src []model.Job) []Job {
 			IDE:             item.IDE,
 			Model:           item.Model,
 			ReasoningEffort: item.ReasoningEffort,
+			RetryPolicy:     item.RetryPolicy,
 			Prompt:          append([]byte(nil), item.Prompt...),
 			SystemPrompt:    item.SystemPrompt,
 			MCPServers:      model.CloneMCPServers(item.MCPServers),
@@ -695,14 +695,20 @@ func (o *ExecutionOrchestrator) runWave(
 	var wg sync.WaitGroup
 	launched := 0
 
+	// A fatal task error fails the whole wave, so the wave context is canceled
+	// to stop siblings instead of letting them run to a discarded result.
+	waveCtx, cancelWave := context.WithCancelCause(ctx)
+	defer cancelWave(nil)
+
 	for idx := range orderedTasks {
 		if err := ctx.Err(); err != nil {
 			break
 		}
+		acquired := false
 		select {
 		case sem <- struct{}{}:
-		case <-ctx.Done():
-			break
+			acquired = true
+		case <-waveCtx.Done():
 		}
 		if err := ctx.Err(); err != nil {
 			break
@@ -710,12 +716,19 @@ func (o *ExecutionOrchestrator) runWave(
 		index := idx
 		task := orderedTasks[idx]
 		results[index].result = TaskRunResult{Task: task, BaseBranch: base.Branch, BaseCommit: base.Commit}
-		wg.Add(1)
 		launched++
+		if waveCtx.Err() != nil {
+			if acquired {
+				<-sem
+			}
+			results[index].canceled = true
+			continue
+		}
+		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			defer func() { <-sem }()
-			prepared, err := o.launcher.PrepareTask(ctx, TaskLaunchSpec{
+			prepared, err := o.launcher.PrepareTask(waveCtx, TaskLaunchSpec{
 				RunID:     strings.TrimSpace(plan.RunID),
 				WaveIndex: waveIndex,
 				WaveTotal: waveTotal,
@@ -723,10 +736,15 @@ func (o *ExecutionOrchestrator) runWave(
 				Base:      base,
 			})
 			if err != nil {
+				if waveCtx.Err() != nil && ctx.Err() == nil {
+					results[index].canceled = true
+					return
+				}
 				errs[index] = fmt.Errorf("prepare task %s: %w", task.ID, err)
+				cancelWave(errs[index])
 				return
 			}
-			outcome, executeErr := prepared.Execute(ctx)
+			outcome, executeErr := prepared.Execute(waveCtx)
 			result := prepared.Result()
 			if result.Task.ID == "" {
 				result.Task = task
@@ -746,9 +764,16 @@ func (o *ExecutionOrchestrator) runWave(
 				outcome:  outcome,
 				err:      executeErr,
 			}
-			if fatalErr := taskExecutionFatalError(task.ID, outcome, executeErr); fatalErr != nil {
-				errs[index] = fatalErr
+			fatalErr := taskExecutionFatalError(task.ID, outcome, executeErr)
+			if fatalErr == nil {
+				return
+			}
+			if waveCtx.Err() != nil && ctx.Err() == nil {
+				results[index].canceled = true
+				return
 			}
+			errs[index] = fatalErr
+			cancelWave(fatalErr)
 		}()
 	}
 	wg.Wait()
@@ -1119,6 +1144,8 @@ type taskExecution struct {
 	outcome   recovery.RunOutcome
 	err       error
 	recovered bool
+	// canceled marks a task stopped because a sibling failed the wave.
+	canceled bool
 }
 
 type parallelRollbackError struct {
@@ -1416,7 +1443,9 @@ func interruptedTaskOutcomes(
 			item.WorktreePath = strings.TrimSpace(execution.result.WorktreePath)
 			item.BaseCommit = strings.TrimSpace(execution.result.BaseCommit)
 			item.WorktreeStatus = initialWorktreeStatus(execution.result.WorktreePath)
-			if executionError := taskExecutionError(execution); executionError != "" {
+			if execution.canceled {
+				item.Status = TaskOutcomeCanceled
+			} else if executionError := taskExecutionError(execution); executionError != "" {
 				item.Error = executionError
 			}
 		}
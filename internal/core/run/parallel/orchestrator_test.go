@@ -50,6 +50,10 @@ func TestParallelExecutionOrchestratorScenarios(t *testing.T) {
 		"Should cancel in-flight work and join workers on context cancellation",
 		runParallelExecutionOrchestratorCancellationTransitionsAndJoinsWorkers,
 	)
+	t.Run(
+		"Should cancel wave siblings when one task fails fatally",
+		runParallelExecutionOrchestratorFatalTaskCancelsWaveSiblings,
+	)
 	t.Run(
 		"Should recover a failed task and mark it recovered after merge",
 		runParallelExecutionOrchestratorRecoversFailedTaskThenMergesRecoveredStatus,
@@ -451,6 +455,57 @@ func runParallelExecutionOrchestratorCancellationTransitionsAndJoinsWorkers(t *t
 	launcher.assertNoActiveWorkers(t)
 }
 
+func runParallelExecutionOrchestratorFatalTaskCancelsWaveSiblings(t *testing.T) {
+	t.Parallel()
+
+	plan := testParallelPlan(t, []model.TaskEntry{
+		testTaskEntry("task_01"),
+		testTaskEntry("task_02"),
+		testTaskEntry("task_03"),
+	}, 2)
+	blocking := newBlockingLauncher(t, len(plan.Tasks))
+	launcher := fakeTaskLauncherFunc(func(ctx context.Context, spec TaskLaunchSpec) (PreparedTaskRun, error) {
+		if spec.Task.ID != "task_01" {
+			return blocking.PrepareTask(ctx, spec)
+		}
+		select {
+		case <-blocking.entered:
+		case <-time.After(2 * time.Second):
+			return nil, errors.New("timed out waiting for sibling task to start")
+		}
+		return &fakePreparedTaskRun{
+			result:     taskRunResultForSpec(spec),
+			executeErr: errors.New("agent process crashed"),
+		}, nil
+	})
+	orchestrator := NewParallelExecutionOrchestrator(newFakeWorktreeLifecycle(), launcher)
+	done := make(chan runResult, 1)
+	go func() {
+		outcome, err := orchestrator.Run(context.Background(), plan)
+		done <- runResult{outcome: outcome, err: err}
+	}()
+
+	result := waitRunResult(t, done)
+	if result.err == nil || !strings.Contains(result.err.Error(), "agent process crashed") {
+		t.Fatalf("Run() error = %v, want the fatal task error", result.err)
+	}
+	if errors.Is(result.err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want sibling cancellation kept out of the run error", result.err)
+	}
+	statusByTask := taskStatusesByID(result.outcome.Tasks)
+	want := map[TaskID]TaskOutcomeStatus{
+		"task_01": TaskOutcomeFailed,
+		"task_02": TaskOutcomeCanceled,
+		"task_03": TaskOutcomeCanceled,
+	}
+	for taskID, status := range want {
+		if got := statusByTask[taskID].Status; got != status {
+			t.Fatalf("%s status = %q, want %q (outcomes: %#v)", taskID, got, status, result.outcome.Tasks)
+		}
+	}
+	blocking.assertNoActiveWorkers(t)
+}
+
 func runParallelExecutionOrchestratorRecoversFailedTaskThenMergesRecoveredStatus(t *testing.T) {
 	t.Parallel()
 
@@ -685,11 +685,12 @@ func streamCursorOrZero(cursor *apicore.StreamCursor) apicore.StreamCursor {
 
 func usageFromSnapshot(src kinds.Usage) model.Usage {
 	return model.Usage{
-		InputTokens:  src.InputTokens,
-		OutputTokens: src.OutputTokens,
-		TotalTokens:  src.TotalTokens,
-		CacheReads:   src.CacheReads,
-		CacheWrites:  src.CacheWrites,
+		InputTokens:     src.InputTokens,
+		OutputTokens:    src.OutputTokens,
+		TotalTokens:     src.TotalTokens,
+		CacheReads:      src.CacheReads,
+		CacheWrites:     src.CacheWrites,
+		ReasoningTokens: src.ReasoningTokens,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -104,6 +105,7 @@ func validateTaskFiles(
 	existingTasks map[string]struct{},
 ) ([]Issue, error) {
 	issues := make([]Issue, 0)
+	dependencies := make(map[string][]string, len(names))
 	for _, name := range names {
 		if err := context.Cause(ctx); err != nil {
 			return nil, fmt.Errorf("validate tasks: %w", err)
@@ -118,10 +120,122 @@ func validateTaskFiles(
 			return nil, fmt.Errorf("parse task %s: %w", name, err)
 		}
 		issues = append(issues, validateTaskFile(path, task, body, legacyKeys, registry, existingTasks)...)
+		dependencies[name] = task.Dependencies
 	}
+	issues = append(issues, validateTaskDependencyCycles(resolvedDir, dependencies)...)
 	return issues, nil
 }
 
+// validateTaskDependencyCycles reports every task whose front matter
+// dependencies loop back to itself, since such a workflow can never start.
+func validateTaskDependencyCycles(resolvedDir string, dependencies map[string][]string) []Issue {
+	ids := make(map[string]string, len(dependencies)*2)
+	names := slices.Sorted(maps.Keys(dependencies))
+	for _, name := range names {
+		id := strings.TrimSuffix(name, filepath.Ext(name))
+		ids[id] = name
+		base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+		if _, ok := ids[base]; !ok {
+			ids[base] = name
+		}
+	}
+
+	nodes := make([]TaskGraphNode, 0, len(names))
+	edges := make([]TaskGraphEdge, 0, len(names))
+	selfLoops := make(map[string]struct{})
+	for _, name := range names {
+		nodes = append(nodes, TaskGraphNode{ID: name})
+		for _, dependency := range dependencies[name] {
+			key := strings.TrimSpace(strings.TrimSuffix(dependency, filepath.Ext(dependency)))
+			from, ok := ids[key]
+			if !ok {
+				continue
+			}
+			if from == name {
+				selfLoops[name] = struct{}{}
+				continue
+			}
+			edges = append(edges, TaskGraphEdge{From: from, To: name})
+		}
+	}
+
+	sorted, _, successors := buildTaskGraphAdjacency(nodes, edges)
+	cycleOf := make(map[string][]string)
+	for _, component := range taskGraphCycleComponents(sorted, successors) {
+		for _, name := range component {
+			cycleOf[name] = component
+		}
+	}
+	for name := range selfLoops {
+		if _, ok := cycleOf[name]; !ok {
+			cycleOf[name] = []string{name}
+		}
+	}
+
+	issues := make([]Issue, 0, len(cycleOf))
+	for _, name := range slices.Sorted(maps.Keys(cycleOf)) {
+		issues = append(issues, Issue{
+			Path:    filepath.Join(resolvedDir, filepath.FromSlash(name)),
+			Field:   "dependencies",
+			Message: fmt.Sprintf("dependencies form a cycle involving: %s", strings.Join(cycleOf[name], ", ")),
+		})
+	}
+	return issues
+}
+
+// taskGraphCycleComponents returns each dependency cycle as its sorted member
+// tasks, meaning each strongly connected component with more than one task.
+// Tasks that only depend on a cycle are not members and are left out.
+func taskGraphCycleComponents(ids []string, successors map[string][]string) [][]string {
+	var (
+		next       int
+		index      = make(map[string]int, len(ids))
+		lowLink    = make(map[string]int, len(ids))
+		onStack    = make(map[string]bool, len(ids))
+		stack      = make([]string, 0, len(ids))
+		components = make([][]string, 0)
+	)
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = next
+		lowLink[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, successor := range successors[id] {
+			if _, seen := index[successor]; !seen {
+				visit(successor)
+				lowLink[id] = min(lowLink[id], lowLink[successor])
+			} else if onStack[successor] {
+				lowLink[id] = min(lowLink[id], index[successor])
+			}
+		}
+		if lowLink[id] != index[id] {
+			return
+		}
+		component := make([]string, 0, 1)
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 {
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	return components
+}
+
 func validateTaskGraphManifestFile(ctx context.Context, resolvedDir string) ([]Issue, error) {
 	manifest, err := ReadTaskGraphManifest(resolvedDir)
 	if errors.Is(err, ErrTaskGraphManifestMissing) {
@@ -15,11 +15,12 @@ func TestValidateRules(t *testing.T) {
 
 	registry := mustTaskRegistry(t)
 	tests := []struct {
-		name        string
-		files       map[string]string
-		wantFields  []string
-		wantOK      bool
-		wantScanned int
+		name         string
+		files        map[string]string
+		wantFields   []string
+		wantMessages map[string]string
+		wantOK       bool
+		wantScanned  int
 	}{
 		{
 			name: "reports missing title",
@@ -94,6 +95,134 @@ func TestValidateRules(t *testing.T) {
 			wantFields:  []string{"dependencies"},
 			wantScanned: 1,
 		},
+		{
+			name: "reports dependency cycle",
+			files: map[string]string{
+				"task_01.md": taskMarkdown(
+					[]string{
+						"status: pending",
+						"title: First Task",
+						"type: backend",
+						"complexity: low",
+						"dependencies:",
+						"  - task_02",
+					},
+					"# Task 1: First Task",
+				),
+				"task_02.md": taskMarkdown(
+					[]string{
+						"status: pending",
+						"title: Second Task",
+						"type: backend",
+						"complexity: low",
+						"dependencies:",
+						"  - task_01",
+					},
+					"# Task 2: Second Task",
+				),
+			},
+			wantFields:  []string{"dependencies", "dependencies"},
+			wantScanned: 2,
+		},
+		{
+			name: "reports only cycle members when another task depends on the cycle",
+			files: map[string]string{
+				"task_01.md": taskMarkdown(
+					[]string{
+						"status: pending",
+						"title: First Task",
+						"type: backend",
+						"complexity: low",
+						"dependencies:",
+						"  - task_02",
+					},
+					"# Task 1: First Task",
+				),
+				"task_02.md": taskMarkdown(
+					[]string{
+						"status: pending",
+						"title: Second Task",
+						"type: backend",
+						"complexity: low",
+						"dependencies:",
+						"  - task_01",
+					},
+					"# Task 2: Second Task",
+				),
+				"task_03.md": taskMarkdown(
+					[]string{
+						"status: pending",
+						"title: Downstream Task",
+						"type: backend",
+						"complexity: low",
+						"dependencies:",
+						"  - task_02",
+					},
+					"# Task 3: Downstream Task",
+				),
+			},
+			wantFields:  []string{"dependencies", "dependencies"},
+			wantScanned: 3,
+		},
+		{
+			name: "reports each disjoint cycle with only its own members",
+			files: map[string]string{
+				"task_01.md": dependentTaskMarkdown(1, "task_02"),
+				"task_02.md": dependentTaskMarkdown(2, "task_01"),
+				"task_03.md": dependentTaskMarkdown(3, "task_04"),
+				"task_04.md": dependentTaskMarkdown(4, "task_03"),
+				"task_05.md": dependentTaskMarkdown(5, "task_05"),
+			},
+			wantFields: []string{"dependencies", "dependencies", "dependencies", "dependencies", "dependencies"},
+			wantMessages: map[string]string{
+				"task_01.md": "dependencies form a cycle involving: task_01.md, task_02.md",
+				"task_02.md": "dependencies form a cycle involving: task_01.md, task_02.md",
+				"task_03.md": "dependencies form a cycle involving: task_03.md, task_04.md",
+				"task_04.md": "dependencies form a cycle involving: task_03.md, task_04.md",
+				"task_05.md": "dependencies form a cycle involving: task_05.md",
+			},
+			wantScanned: 5,
+		},
+		{
+			name: "reports self dependency",
+			files: map[string]string{
+				"task_01.md": taskMarkdown(
+					[]string{
+						"status: pending",
+						"title: Example Task",
+						"type: backend",
+						"complexity: low",
+						"dependencies:",
+						"  - task_01",
+					},
+					"# Task 1: Example Task",
+				),
+			},
+			wantFields:  []string{"dependencies"},
+			wantScanned: 1,
+		},
+		{
+			name: "accepts acyclic dependencies",
+			files: map[string]string{
+				"task_01.md": taskMarkdown(
+					[]string{"status: pending", "title: First Task", "type: backend", "complexity: low"},
+					"# Task 1: First Task",
+				),
+				"task_02.md": taskMarkdown(
+					[]string{
+						"status: pending",
+						"title: Second Task",
+						"type: backend",
+						"complexity: low",
+						"dependencies:",
+						"  - task_01",
+					},
+					"# Task 2: Second Task",
+				),
+			},
+			wantOK:      true,
+			wantScanned: 2,
+		},
 		{
 			name: "reports legacy keys",
 			files: map[string]string{
@@ -155,10 +284,30 @@ func TestValidateRules(t *testing.T) {
 			if !slices.Equal(gotFields, tt.wantFields) {
 				t.Fatalf("unexpected issue fields\nwant: %#v\ngot:  %#v", tt.wantFields, gotFields)
 			}
+			for _, issue := range report.Issues {
+				want, ok := tt.wantMessages[filepath.Base(issue.Path)]
+				if ok && issue.Message != want {
+					t.Fatalf("unexpected message for %s\nwant: %q\ngot:  %q", issue.Path, want, issue.Message)
+				}
+			}
 		})
 	}
 }
 
+func dependentTaskMarkdown(number int, dependencies ...string) string {
+	frontmatter := []string{
+		"status: pending",
+		fmt.Sprintf("title: Task %d", number),
+		"type: backend",
+		"complexity: low",
+		"dependencies:",
+	}
+	for _, dependency := range dependencies {
+		frontmatter = append(frontmatter, "  - "+dependency)
+	}
+	return taskMarkdown(frontmatter, fmt.Sprintf("# Task %d: Task %d", number, number))
+}
+
 func TestValidateTypeIssueListsAllowedValues(t *testing.T) {
 	t.Parallel()
 
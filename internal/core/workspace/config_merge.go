@@ -240,6 +240,33 @@ func mergeRunsConfig(base, overlay RunsConfig) RunsConfig {
 		ShutdownDrainTimeout: cloneOptionalValue(
 			preferOverlay(base.ShutdownDrainTimeout, overlay.ShutdownDrainTimeout),
 		),
+		MaxConcurrentPerWorkflow: cloneOptionalValue(
+			preferOverlay(base.MaxConcurrentPerWorkflow, overlay.MaxConcurrentPerWorkflow),
+		),
+		WorkflowLimitPolicy: cloneOptionalValue(
+			preferOverlay(base.WorkflowLimitPolicy, overlay.WorkflowLimitPolicy),
+		),
+		MaxConcurrentPerWorkspace: cloneOptionalValue(
+			preferOverlay(base.MaxConcurrentPerWorkspace, overlay.MaxConcurrentPerWorkspace),
+		),
+		WorkspaceLimitPolicy: cloneOptionalValue(
+			preferOverlay(base.WorkspaceLimitPolicy, overlay.WorkspaceLimitPolicy),
+		),
+		DedupeWindow: cloneOptionalValue(
+			preferOverlay(base.DedupeWindow, overlay.DedupeWindow),
+		),
+		PurgeInterval: cloneOptionalValue(
+			preferOverlay(base.PurgeInterval, overlay.PurgeInterval),
+		),
+		MaxRunDuration: cloneOptionalValue(
+			preferOverlay(base.MaxRunDuration, overlay.MaxRunDuration),
+		),
+		ReapInterval: cloneOptionalValue(
+			preferOverlay(base.ReapInterval, overlay.ReapInterval),
+		),
+		ArchiveDir: cloneOptionalValue(
+			preferOverlay(base.ArchiveDir, overlay.ArchiveDir),
+		),
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	compozyconfig "github.com/compozy/compozy/internal/config"
 )
@@ -1171,6 +1172,210 @@ run_multiple_mode = "invalid"
 	})
 }
 
+func TestLoadConfigParsesRunConcurrencyLimits(t *testing.T) {
+	t.Run("Should default to unlimited with the reject policy", func(t *testing.T) {
+		root := t.TempDir()
+
+		cfg, _, err := loadConfigWithIsolatedHome(t, root)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		if got := cfg.Runs.EffectiveMaxConcurrentPerWorkflow(); got != 0 {
+			t.Fatalf("EffectiveMaxConcurrentPerWorkflow() = %d, want 0", got)
+		}
+		if got := cfg.Runs.EffectiveWorkflowLimitPolicy(); got != WorkflowLimitPolicyReject {
+			t.Fatalf("EffectiveWorkflowLimitPolicy() = %q, want %q", got, WorkflowLimitPolicyReject)
+		}
+		if got := cfg.Runs.EffectiveMaxConcurrentPerWorkspace(); got != 0 {
+			t.Fatalf("EffectiveMaxConcurrentPerWorkspace() = %d, want 0", got)
+		}
+		if got := cfg.Runs.EffectiveWorkspaceLimitPolicy(); got != WorkflowLimitPolicyReject {
+			t.Fatalf("EffectiveWorkspaceLimitPolicy() = %q, want %q", got, WorkflowLimitPolicyReject)
+		}
+	})
+
+	t.Run("Should parse the limit and queue policy", func(t *testing.T) {
+		root := t.TempDir()
+		writeWorkspaceConfig(t, root, `
+[runs]
+max_concurrent_per_workflow = 2
+workflow_limit_policy = "queue"
+max_concurrent_per_workspace = 5
+workspace_limit_policy = "queue"
+`)
+
+		cfg, _, err := loadConfigWithIsolatedHome(t, root)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		if got := cfg.Runs.EffectiveMaxConcurrentPerWorkflow(); got != 2 {
+			t.Fatalf("EffectiveMaxConcurrentPerWorkflow() = %d, want 2", got)
+		}
+		if got := cfg.Runs.EffectiveWorkflowLimitPolicy(); got != WorkflowLimitPolicyQueue {
+			t.Fatalf("EffectiveWorkflowLimitPolicy() = %q, want %q", got, WorkflowLimitPolicyQueue)
+		}
+		if got := cfg.Runs.EffectiveMaxConcurrentPerWorkspace(); got != 5 {
+			t.Fatalf("EffectiveMaxConcurrentPerWorkspace() = %d, want 5", got)
+		}
+		if got := cfg.Runs.EffectiveWorkspaceLimitPolicy(); got != WorkflowLimitPolicyQueue {
+			t.Fatalf("EffectiveWorkspaceLimitPolicy() = %q, want %q", got, WorkflowLimitPolicyQueue)
+		}
+	})
+
+	cases := []struct {
+		name    string
+		content string
+		field   string
+	}{
+		{
+			name:    "negative limit",
+			content: "[runs]\nmax_concurrent_per_workflow = -1\n",
+			field:   "runs.max_concurrent_per_workflow",
+		},
+		{
+			name:    "unknown policy",
+			content: "[runs]\nworkflow_limit_policy = \"drop\"\n",
+			field:   "runs.workflow_limit_policy",
+		},
+		{
+			name:    "negative workspace limit",
+			content: "[runs]\nmax_concurrent_per_workspace = -1\n",
+			field:   "runs.max_concurrent_per_workspace",
+		},
+		{
+			name:    "unknown workspace policy",
+			content: "[runs]\nworkspace_limit_policy = \"drop\"\n",
+			field:   "runs.workspace_limit_policy",
+		},
+	}
+	for _, tc := range cases {
+		t.Run("Should reject "+tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeWorkspaceConfig(t, root, tc.content)
+
+			_, _, err := loadConfigWithIsolatedHome(t, root)
+			if err == nil || !strings.Contains(err.Error(), tc.field) {
+				t.Fatalf("load config error = %v, want %s error", err, tc.field)
+			}
+		})
+	}
+}
+
+func TestLoadConfigParsesRunDedupeWindow(t *testing.T) {
+	t.Run("Should default to disabled", func(t *testing.T) {
+		root := t.TempDir()
+
+		cfg, _, err := loadConfigWithIsolatedHome(t, root)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		if got := cfg.Runs.EffectiveDedupeWindow(); got != 0 {
+			t.Fatalf("EffectiveDedupeWindow() = %s, want 0", got)
+		}
+	})
+
+	t.Run("Should parse the window", func(t *testing.T) {
+		root := t.TempDir()
+		writeWorkspaceConfig(t, root, "[runs]\ndedupe_window = \"10m\"\n")
+
+		cfg, _, err := loadConfigWithIsolatedHome(t, root)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		if got := cfg.Runs.EffectiveDedupeWindow(); got != 10*time.Minute {
+			t.Fatalf("EffectiveDedupeWindow() = %s, want 10m", got)
+		}
+	})
+
+	for _, content := range []string{
+		"[runs]\ndedupe_window = \"soon\"\n",
+		"[runs]\ndedupe_window = \"-1m\"\n",
+	} {
+		t.Run("Should reject "+content, func(t *testing.T) {
+			root := t.TempDir()
+			writeWorkspaceConfig(t, root, content)
+
+			_, _, err := loadConfigWithIsolatedHome(t, root)
+			if err == nil || !strings.Contains(err.Error(), "runs.dedupe_window") {
+				t.Fatalf("load config error = %v, want runs.dedupe_window error", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRejectsInvalidRunPurgeInterval(t *testing.T) {
+	for _, content := range []string{
+		"[runs]\npurge_interval = \"daily\"\n",
+		"[runs]\npurge_interval = \"-1h\"\n",
+	} {
+		t.Run("Should reject "+content, func(t *testing.T) {
+			root := t.TempDir()
+			writeWorkspaceConfig(t, root, content)
+
+			_, _, err := loadConfigWithIsolatedHome(t, root)
+			if err == nil || !strings.Contains(err.Error(), "runs.purge_interval") {
+				t.Fatalf("load config error = %v, want runs.purge_interval error", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRunReaperSettings(t *testing.T) {
+	t.Run("Should load max_run_duration and reap_interval", func(t *testing.T) {
+		root := t.TempDir()
+		writeWorkspaceConfig(t, root, "[runs]\nmax_run_duration = \"6h\"\nreap_interval = \"30s\"\n")
+
+		cfg, _, err := loadConfigWithIsolatedHome(t, root)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		assertOptionalString(t, "runs.max_run_duration", cfg.Runs.MaxRunDuration, ptrString("6h"))
+		assertOptionalString(t, "runs.reap_interval", cfg.Runs.ReapInterval, ptrString("30s"))
+	})
+
+	for _, tc := range []struct {
+		content string
+		field   string
+	}{
+		{content: "[runs]\nmax_run_duration = \"forever\"\n", field: "runs.max_run_duration"},
+		{content: "[runs]\nreap_interval = \"-1m\"\n", field: "runs.reap_interval"},
+	} {
+		t.Run("Should reject "+tc.content, func(t *testing.T) {
+			root := t.TempDir()
+			writeWorkspaceConfig(t, root, tc.content)
+
+			_, _, err := loadConfigWithIsolatedHome(t, root)
+			if err == nil || !strings.Contains(err.Error(), tc.field) {
+				t.Fatalf("load config error = %v, want %s error", err, tc.field)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRunArchiveDir(t *testing.T) {
+	t.Run("Should load an absolute archive_dir", func(t *testing.T) {
+		root := t.TempDir()
+		archiveDir := filepath.Join(root, "archive")
+		writeWorkspaceConfig(t, root, "[runs]\narchive_dir = \""+archiveDir+"\"\n")
+
+		cfg, _, err := loadConfigWithIsolatedHome(t, root)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		assertOptionalString(t, "runs.archive_dir", cfg.Runs.ArchiveDir, ptrString(archiveDir))
+	})
+
+	t.Run("Should reject a relative archive_dir", func(t *testing.T) {
+		root := t.TempDir()
+		writeWorkspaceConfig(t, root, "[runs]\narchive_dir = \"archive\"\n")
+
+		_, _, err := loadConfigWithIsolatedHome(t, root)
+		if err == nil || !strings.Contains(err.Error(), "runs.archive_dir") {
+			t.Fatalf("load config error = %v, want runs.archive_dir error", err)
+		}
+	})
+}
+
 func TestLoadConfigAcceptsTaskRunMultipleModeAndRejectsUnknownTaskRunKeys(t *testing.T) {
 	t.Run("Should accept run_multiple_mode", func(t *testing.T) {
 		root := t.TempDir()
@@ -1554,6 +1759,53 @@ ide = "codex"
 	}
 }
 
+func TestLoadConfigValidatesTaskRuntimeRuleRetryOverrides(t *testing.T) {
+	t.Run("Should load timeout and retry overrides", func(t *testing.T) {
+		isolateWorkspaceConfigHome(t)
+
+		root := t.TempDir()
+		writeWorkspaceConfig(t, root, `
+[tasks.run]
+[[tasks.run.task_runtime_rules]]
+type = "migration"
+timeout = "45m"
+max_retries = 3
+retry_backoff_multiplier = 2.0
+`)
+
+		cfg, _, err := LoadConfig(context.Background(), root)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		rules := cfg.Tasks.Run.TaskRuntimeRules
+		if rules == nil || len(*rules) != 1 {
+			t.Fatalf("unexpected tasks.run.task_runtime_rules: %#v", rules)
+		}
+		rule := (*rules)[0]
+		if rule.Timeout == nil || *rule.Timeout != "45m" || rule.MaxRetries == nil || *rule.MaxRetries != 3 ||
+			rule.RetryBackoffMultiplier == nil || *rule.RetryBackoffMultiplier != 2 {
+			t.Fatalf("unexpected retry overrides: %#v", rule)
+		}
+	})
+
+	t.Run("Should reject non-positive timeouts", func(t *testing.T) {
+		isolateWorkspaceConfigHome(t)
+
+		root := t.TempDir()
+		writeWorkspaceConfig(t, root, `
+[tasks.run]
+[[tasks.run.task_runtime_rules]]
+type = "migration"
+timeout = "0s"
+`)
+
+		_, _, err := LoadConfig(context.Background(), root)
+		if err == nil || !strings.Contains(err.Error(), "tasks.run.task_runtime_rules[0].timeout must be greater than zero") {
+			t.Fatalf("expected timeout validation error, got %v", err)
+		}
+	})
+}
+
 func TestLoadConfigRejectsUnsupportedStartTaskRuntimeRuleWorkflow(t *testing.T) {
 	isolateWorkspaceConfigHome(t)
 
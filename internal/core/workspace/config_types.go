@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"strings"
+	"time"
 
 	"github.com/compozy/compozy/internal/core/model"
 )
@@ -23,6 +24,12 @@ const (
 
 	DefaultParallelTasksEnabled        = false
 	DefaultParallelTasksMaxConcurrency = 4
+
+	// WorkflowLimitPolicyReject fails a run start once its workflow is at
+	// runs.max_concurrent_per_workflow; WorkflowLimitPolicyQueue waits for a slot.
+	// runs.workspace_limit_policy takes the same values.
+	WorkflowLimitPolicyReject = "reject"
+	WorkflowLimitPolicyQueue  = "queue"
 )
 
 type Context struct {
@@ -225,10 +232,77 @@ type ExecConfig struct {
 }
 
 type RunsConfig struct {
-	DefaultAttachMode    *string `toml:"default_attach_mode"`
-	KeepTerminalDays     *int    `toml:"keep_terminal_days"`
-	KeepMax              *int    `toml:"keep_max"`
-	ShutdownDrainTimeout *string `toml:"shutdown_drain_timeout"`
+	DefaultAttachMode         *string `toml:"default_attach_mode"`
+	KeepTerminalDays          *int    `toml:"keep_terminal_days"`
+	KeepMax                   *int    `toml:"keep_max"`
+	ShutdownDrainTimeout      *string `toml:"shutdown_drain_timeout"`
+	MaxConcurrentPerWorkflow  *int    `toml:"max_concurrent_per_workflow"`
+	WorkflowLimitPolicy       *string `toml:"workflow_limit_policy"`
+	MaxConcurrentPerWorkspace *int    `toml:"max_concurrent_per_workspace"`
+	WorkspaceLimitPolicy      *string `toml:"workspace_limit_policy"`
+	DedupeWindow              *string `toml:"dedupe_window"`
+	PurgeInterval             *string `toml:"purge_interval"`
+	MaxRunDuration            *string `toml:"max_run_duration"`
+	ReapInterval              *string `toml:"reap_interval"`
+	ArchiveDir                *string `toml:"archive_dir"`
+}
+
+// EffectiveMaxConcurrentPerWorkflow returns the per-workflow active run limit.
+// Zero means unlimited and is the default.
+func (cfg RunsConfig) EffectiveMaxConcurrentPerWorkflow() int {
+	if cfg.MaxConcurrentPerWorkflow == nil {
+		return 0
+	}
+	return *cfg.MaxConcurrentPerWorkflow
+}
+
+// EffectiveWorkflowLimitPolicy returns how run starts behave at the
+// per-workflow limit, defaulting to WorkflowLimitPolicyReject.
+func (cfg RunsConfig) EffectiveWorkflowLimitPolicy() string {
+	if cfg.WorkflowLimitPolicy == nil {
+		return WorkflowLimitPolicyReject
+	}
+	policy := strings.TrimSpace(*cfg.WorkflowLimitPolicy)
+	if policy == "" {
+		return WorkflowLimitPolicyReject
+	}
+	return policy
+}
+
+// EffectiveMaxConcurrentPerWorkspace returns the per-workspace active run
+// limit. Zero means unlimited and is the default.
+func (cfg RunsConfig) EffectiveMaxConcurrentPerWorkspace() int {
+	if cfg.MaxConcurrentPerWorkspace == nil {
+		return 0
+	}
+	return *cfg.MaxConcurrentPerWorkspace
+}
+
+// EffectiveWorkspaceLimitPolicy returns how run starts behave at the
+// per-workspace limit, defaulting to WorkflowLimitPolicyReject.
+func (cfg RunsConfig) EffectiveWorkspaceLimitPolicy() string {
+	if cfg.WorkspaceLimitPolicy == nil {
+		return WorkflowLimitPolicyReject
+	}
+	policy := strings.TrimSpace(*cfg.WorkspaceLimitPolicy)
+	if policy == "" {
+		return WorkflowLimitPolicyReject
+	}
+	return policy
+}
+
+// EffectiveDedupeWindow returns how recently an identical top-level workflow
+// run must have started for a new start to join it. Zero disables dedupe and
+// is the default; invalid values are rejected during config validation.
+func (cfg RunsConfig) EffectiveDedupeWindow() time.Duration {
+	if cfg.DedupeWindow == nil {
+		return 0
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(*cfg.DedupeWindow))
+	if err != nil || window < 0 {
+		return 0
+	}
+	return window
 }
 
 type AgentRecoveryConfig struct {
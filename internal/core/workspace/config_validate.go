@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -332,6 +333,61 @@ func validateRuns(scope string, cfg RunsConfig) error {
 			*cfg.KeepMax,
 		)
 	}
+	if cfg.MaxConcurrentPerWorkflow != nil && *cfg.MaxConcurrentPerWorkflow < 0 {
+		return fmt.Errorf(
+			"%s must be zero or greater (got %d)",
+			configFieldName(scope, "runs.max_concurrent_per_workflow"),
+			*cfg.MaxConcurrentPerWorkflow,
+		)
+	}
+	if err := validateRunLimitPolicy(scope, "runs.workflow_limit_policy", cfg.WorkflowLimitPolicy); err != nil {
+		return err
+	}
+	if cfg.MaxConcurrentPerWorkspace != nil && *cfg.MaxConcurrentPerWorkspace < 0 {
+		return fmt.Errorf(
+			"%s must be zero or greater (got %d)",
+			configFieldName(scope, "runs.max_concurrent_per_workspace"),
+			*cfg.MaxConcurrentPerWorkspace,
+		)
+	}
+	if err := validateRunLimitPolicy(scope, "runs.workspace_limit_policy", cfg.WorkspaceLimitPolicy); err != nil {
+		return err
+	}
+	if cfg.DedupeWindow != nil {
+		window, err := time.ParseDuration(strings.TrimSpace(*cfg.DedupeWindow))
+		if err != nil {
+			return fmt.Errorf("%s: %w", configFieldName(scope, "runs.dedupe_window"), err)
+		}
+		if window < 0 {
+			return fmt.Errorf(
+				"%s must be zero or greater (got %s)",
+				configFieldName(scope, "runs.dedupe_window"),
+				*cfg.DedupeWindow,
+			)
+		}
+	}
+	for _, interval := range []struct {
+		field string
+		value *string
+	}{
+		{field: "runs.purge_interval", value: cfg.PurgeInterval},
+		{field: "runs.max_run_duration", value: cfg.MaxRunDuration},
+		{field: "runs.reap_interval", value: cfg.ReapInterval},
+	} {
+		if err := validateRunInterval(scope, interval.field, interval.value); err != nil {
+			return err
+		}
+	}
+	if cfg.ArchiveDir != nil {
+		archiveDir := strings.TrimSpace(*cfg.ArchiveDir)
+		if archiveDir != "" && !filepath.IsAbs(archiveDir) {
+			return fmt.Errorf(
+				"%s must be an absolute path (got %q)",
+				configFieldName(scope, "runs.archive_dir"),
+				*cfg.ArchiveDir,
+			)
+		}
+	}
 	if cfg.ShutdownDrainTimeout != nil {
 		timeout := strings.TrimSpace(*cfg.ShutdownDrainTimeout)
 		if timeout == "" {
@@ -352,6 +408,40 @@ func validateRuns(scope string, cfg RunsConfig) error {
 	return nil
 }
 
+// validateRunInterval accepts zero, which turns the matching daemon behavior
+// off, and rejects negative or unparsable durations.
+func validateRunInterval(scope string, field string, value *string) error {
+	if value == nil {
+		return nil
+	}
+	interval, err := time.ParseDuration(strings.TrimSpace(*value))
+	if err != nil {
+		return fmt.Errorf("%s: %w", configFieldName(scope, field), err)
+	}
+	if interval < 0 {
+		return fmt.Errorf("%s must be zero or greater (got %s)", configFieldName(scope, field), *value)
+	}
+	return nil
+}
+
+func validateRunLimitPolicy(scope string, field string, value *string) error {
+	if value == nil {
+		return nil
+	}
+	switch policy := strings.TrimSpace(*value); policy {
+	case WorkflowLimitPolicyReject, WorkflowLimitPolicyQueue:
+		return nil
+	default:
+		return fmt.Errorf(
+			"%s must be %q or %q (got %q)",
+			configFieldName(scope, field),
+			WorkflowLimitPolicyReject,
+			WorkflowLimitPolicyQueue,
+			policy,
+		)
+	}
+}
+
 func validateRecovery(scope string, cfg AgentRecoveryConfig) error {
 	return validateAgentRecoveryConfig(scope, "recovery", cfg)
 }
@@ -698,7 +788,11 @@ func validateTaskRunRuntimeRules(scope string, rules *[]model.TaskRuntimeRule) e
 			return fmt.Errorf("%s.type is required", fieldPrefix)
 		}
 		if !rule.HasOverride() {
-			return fmt.Errorf("%s must define at least one of ide, model, or reasoning_effort", fieldPrefix)
+			return fmt.Errorf(
+				"%s must define at least one of ide, model, reasoning_effort, timeout, max_retries, "+
+					"or retry_backoff_multiplier",
+				fieldPrefix,
+			)
 		}
 		if err := validateTaskRuntimeRuleRuntime(fieldPrefix, rule); err != nil {
 			return err
@@ -723,6 +817,25 @@ func validateTaskRuntimeRuleRuntime(fieldPrefix string, rule model.TaskRuntimeRu
 	if err := validateReasoningEffortValue(fieldPrefix+".reasoning_effort", rule.ReasoningEffort); err != nil {
 		return err
 	}
+	if rule.Timeout != nil {
+		timeout, err := time.ParseDuration(strings.TrimSpace(*rule.Timeout))
+		if err != nil {
+			return fmt.Errorf("%s.timeout: %w", fieldPrefix, err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("%s.timeout must be greater than zero (got %s)", fieldPrefix, *rule.Timeout)
+		}
+	}
+	if rule.MaxRetries != nil && *rule.MaxRetries < 0 {
+		return fmt.Errorf("%s.max_retries must be zero or greater (got %d)", fieldPrefix, *rule.MaxRetries)
+	}
+	if rule.RetryBackoffMultiplier != nil && *rule.RetryBackoffMultiplier <= 0 {
+		return fmt.Errorf(
+			"%s.retry_backoff_multiplier must be greater than zero (got %v)",
+			fieldPrefix,
+			*rule.RetryBackoffMultiplier,
+		)
+	}
 	return nil
 }
 
@@ -0,0 +1,284 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	extensions "github.com/compozy/compozy/internal/core/extension"
+	eventspkg "github.com/compozy/compozy/pkg/compozy/events"
+	"github.com/compozy/compozy/pkg/compozy/events/kinds"
+)
+
+const (
+	customMetricPrefix      = "compozy_custom_"
+	customMetricDefaultHelp = "Custom metric emitted by an extension"
+	maxCustomMetricSeries   = 1000
+)
+
+type customMetricFamily struct {
+	metricType string
+	help       string
+	series     map[string]*customMetricSeries
+}
+
+type customMetricSeries struct {
+	labels []customMetricLabel
+	value  float64
+}
+
+type customMetricLabel struct {
+	name  string
+	value string
+}
+
+// startCustomMetricObserver folds metric extension events published on the
+// run event bus into the daemon-lifetime custom metric families.
+func (m *RunManager) startCustomMetricObserver(active *activeRun, runLabels map[string]string) {
+	if m == nil || active == nil || active.scope == nil {
+		return
+	}
+	bus := active.scope.RunEventBus()
+	if bus == nil {
+		return
+	}
+	_, events, unsubscribe := bus.Subscribe()
+	baseLabels := customMetricBaseLabels(active, runLabels)
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				m.observeCustomMetricEvent(active.runID, baseLabels, event)
+			case <-active.done:
+				for {
+					select {
+					case event, ok := <-events:
+						if !ok {
+							return
+						}
+						m.observeCustomMetricEvent(active.runID, baseLabels, event)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+}
+
+func (m *RunManager) observeCustomMetricEvent(runID string, baseLabels map[string]string, event eventspkg.Event) {
+	if event.Kind != eventspkg.EventKindExtensionEvent {
+		return
+	}
+	var envelope kinds.ExtensionEventPayload
+	if err := json.Unmarshal(event.Payload, &envelope); err != nil ||
+		envelope.Kind != kinds.ExtensionMetricEventKind {
+		return
+	}
+	metric, err := extensions.ParseMetricPayload(envelope.Payload)
+	if err != nil {
+		slog.Warn("daemon: ignore invalid custom metric", "run_id", runID, "extension", envelope.Extension, "error", err)
+		return
+	}
+	labels := maps.Clone(baseLabels)
+	if labels == nil {
+		labels = make(map[string]string, len(metric.Labels))
+	}
+	maps.Copy(labels, metric.Labels)
+	maps.Copy(labels, customMetricReservedLabels(baseLabels))
+	m.recordCustomMetric(metric, labels)
+}
+
+func (m *RunManager) recordCustomMetric(metric kinds.ExtensionMetricPayload, labels map[string]string) {
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+
+	if m.customMetrics == nil {
+		m.customMetrics = make(map[string]*customMetricFamily)
+	}
+	family := m.customMetrics[metric.Name]
+	if family == nil {
+		help := metric.Help
+		if help == "" {
+			help = customMetricDefaultHelp
+		}
+		family = &customMetricFamily{
+			metricType: metric.Type,
+			help:       help,
+			series:     make(map[string]*customMetricSeries),
+		}
+		m.customMetrics[metric.Name] = family
+	}
+	if family.metricType != metric.Type {
+		m.customMetricDrops++
+		return
+	}
+
+	sorted := sortedCustomMetricLabels(labels)
+	key := customMetricSeriesKey(sorted)
+	series := family.series[key]
+	if series == nil {
+		if m.customMetricSeriesCount >= maxCustomMetricSeries {
+			m.customMetricDrops++
+			return
+		}
+		series = &customMetricSeries{labels: sorted}
+		family.series[key] = series
+		m.customMetricSeriesCount++
+	}
+	if family.metricType == kinds.MetricTypeGauge {
+		series.value = metric.Value
+		return
+	}
+	series.value += metric.Value
+}
+
+func (m *RunManager) writeCustomMetrics(builder *strings.Builder) {
+	if m == nil {
+		return
+	}
+	m.metricsMu.RLock()
+	defer m.metricsMu.RUnlock()
+
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_custom_metric_samples_dropped_total",
+		"counter",
+		"Custom metric samples dropped for type conflicts or the series limit",
+	)
+	fmt.Fprintf(builder, "daemon_custom_metric_samples_dropped_total %d\n", m.customMetricDrops)
+
+	for _, name := range slices.Sorted(maps.Keys(m.customMetrics)) {
+		family := m.customMetrics[name]
+		metricName := customMetricPrefix + name
+		writePrometheusMetricPrelude(builder, metricName, family.metricType, family.help)
+		for _, key := range slices.Sorted(maps.Keys(family.series)) {
+			series := family.series[key]
+			builder.WriteString(metricName)
+			if len(series.labels) > 0 {
+				builder.WriteByte('{')
+				for idx, label := range series.labels {
+					if idx > 0 {
+						builder.WriteByte(',')
+					}
+					builder.WriteString(label.name)
+					builder.WriteString(`="`)
+					builder.WriteString(prometheusLabelValue(label.value))
+					builder.WriteByte('"')
+				}
+				builder.WriteByte('}')
+			}
+			builder.WriteByte(' ')
+			builder.WriteString(strconv.FormatFloat(series.value, 'g', -1, 64))
+			builder.WriteByte('\n')
+		}
+	}
+}
+
+// customMetricBaseLabels tags every sample with the run's workflow and mode
+// plus its run labels, rewritten into valid Prometheus label names. Keys that
+// are already valid names claim their name first; rewritten keys follow in
+// sorted order, so when several keys map to one name the winner is stable.
+// Keys that would shadow workflow or mode, or use the reserved "__" prefix,
+// are dropped.
+func customMetricBaseLabels(active *activeRun, runLabels map[string]string) map[string]string {
+	labels := make(map[string]string, len(runLabels)+2)
+	keys := slices.Sorted(maps.Keys(runLabels))
+	for _, exact := range []bool{true, false} {
+		for _, key := range keys {
+			name := prometheusLabelName(key)
+			if (name == key) != exact {
+				continue
+			}
+			if _, taken := labels[name]; taken || !usableRunLabelName(name) {
+				slog.Warn(
+					"daemon: drop run label from custom metrics",
+					"run_id", active.runID,
+					"label", key,
+					"metric_label", name,
+				)
+				continue
+			}
+			labels[name] = runLabels[key]
+		}
+	}
+	if workflow := strings.TrimSpace(active.workflowSlug); workflow != "" {
+		labels["workflow"] = workflow
+	}
+	labels["mode"] = active.mode
+	return labels
+}
+
+func customMetricReservedLabels(baseLabels map[string]string) map[string]string {
+	reserved := make(map[string]string, 2)
+	for _, key := range []string{"workflow", "mode"} {
+		if value, ok := baseLabels[key]; ok {
+			reserved[key] = value
+		}
+	}
+	return reserved
+}
+
+func usableRunLabelName(name string) bool {
+	switch {
+	case name == "", strings.HasPrefix(name, "__"):
+		return false
+	case name == "workflow", name == "mode":
+		return false
+	default:
+		return true
+	}
+}
+
+func prometheusLabelName(key string) string {
+	var builder strings.Builder
+	for idx, r := range key {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			builder.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if idx == 0 {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte('_')
+		}
+	}
+	return builder.String()
+}
+
+// prometheusLabelValueEscaper applies the text exposition format escapes.
+// Other runes, including tabs and control characters, are valid as is.
+var prometheusLabelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func prometheusLabelValue(value string) string {
+	return prometheusLabelValueEscaper.Replace(value)
+}
+
+func sortedCustomMetricLabels(labels map[string]string) []customMetricLabel {
+	sorted := make([]customMetricLabel, 0, len(labels))
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		sorted = append(sorted, customMetricLabel{name: name, value: labels[name]})
+	}
+	return sorted
+}
+
+func customMetricSeriesKey(labels []customMetricLabel) string {
+	var builder strings.Builder
+	for _, label := range labels {
+		builder.WriteString(label.name)
+		builder.WriteByte(0)
+		builder.WriteString(label.value)
+		builder.WriteByte(0)
+	}
+	return builder.String()
+}
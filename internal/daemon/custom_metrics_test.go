@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compozy/compozy/internal/core/model"
+	eventspkg "github.com/compozy/compozy/pkg/compozy/events"
+	"github.com/compozy/compozy/pkg/compozy/events/kinds"
+)
+
+func TestCustomMetricObserverExportsExtensionMetrics(t *testing.T) {
+	t.Parallel()
+
+	bus := eventspkg.New[eventspkg.Event](16)
+	t.Cleanup(func() {
+		_ = bus.Close(context.Background())
+	})
+	manager := &RunManager{}
+	active := &activeRun{
+		runID:        "run-metrics",
+		mode:         runModeTask,
+		workflowSlug: "demo",
+		scope:        &model.BaseRunScope{EventBus: bus},
+		done:         make(chan struct{}),
+	}
+	manager.startCustomMetricObserver(active, map[string]string{"team.name": "core"})
+
+	for _, payload := range []string{
+		`{"name":"documents_processed","value":2}`,
+		`{"name":"documents_processed","value":3,"labels":{"workflow":"spoofed"}}`,
+		`{"name":"queue_depth","type":"gauge","value":5,"help":"Pending documents"}`,
+		`{"name":"queue_depth","type":"gauge","value":4}`,
+		`{"name":"documents_processed","type":"gauge","value":9}`,
+		`{"name":"bad-name","value":1}`,
+	} {
+		publishMetricEvent(t, bus, kinds.ExtensionMetricEventKind, payload)
+	}
+	publishMetricEvent(t, bus, "custom.signal", `{"name":"ignored_metric","value":1}`)
+	close(active.done)
+
+	wantLines := []string{
+		"# TYPE compozy_custom_documents_processed counter",
+		`compozy_custom_documents_processed{mode="task",team_name="core",workflow="demo"} 5`,
+		"# HELP compozy_custom_queue_depth Pending documents",
+		"# TYPE compozy_custom_queue_depth gauge",
+		`compozy_custom_queue_depth{mode="task",team_name="core",workflow="demo"} 4`,
+		"daemon_custom_metric_samples_dropped_total 1",
+	}
+	var body string
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var builder strings.Builder
+		manager.writeCustomMetrics(&builder)
+		body = builder.String()
+		if containsAllLines(body, wantLines) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !containsAllLines(body, wantLines) {
+		t.Fatalf("custom metrics =\n%s\nwant lines %q", body, wantLines)
+	}
+	if strings.Contains(body, "ignored_metric") || strings.Contains(body, "bad-name") {
+		t.Fatalf("custom metrics =\n%s\nwant non-metric and invalid events ignored", body)
+	}
+}
+
+func TestPrometheusLabelNameRewritesRunLabelKeys(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]string{
+		"team":             "team",
+		"team.name":        "team_name",
+		"example.com/tier": "example_com_tier",
+		"9lives":           "_9lives",
+	} {
+		if got := prometheusLabelName(input); got != want {
+			t.Fatalf("prometheusLabelName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestCustomMetricBaseLabelsResolvesRunLabelCollisions(t *testing.T) {
+	t.Parallel()
+
+	active := &activeRun{runID: "run-metrics", mode: runModeTask, workflowSlug: "demo"}
+	runLabels := map[string]string{
+		"team-a":    "dash",
+		"team.a":    "dot",
+		"team_a":    "exact",
+		"env-x":     "dash",
+		"env.x":     "dot",
+		"workflow":  "spoofed",
+		"mode":      "spoofed",
+		"__name__":  "spoofed",
+		"--private": "spoofed",
+	}
+	want := map[string]string{
+		"team_a":   "exact",
+		"env_x":    "dash",
+		"workflow": "demo",
+		"mode":     runModeTask,
+	}
+	for attempt := 0; attempt < 20; attempt++ {
+		got := customMetricBaseLabels(active, runLabels)
+		if !maps.Equal(got, want) {
+			t.Fatalf("customMetricBaseLabels() = %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestPrometheusLabelValueUsesExpositionEscapes(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]string{
+		"core":           "core",
+		`say "hi"`:       `say \"hi\"`,
+		`C:\tmp`:         `C:\\tmp`,
+		"line\nbreak":    `line\nbreak`,
+		"tab\there":      "tab\there",
+		"bell\x07":       "bell\x07",
+		"snowman \u2603": "snowman \u2603",
+	} {
+		if got := prometheusLabelValue(input); got != want {
+			t.Fatalf("prometheusLabelValue(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func publishMetricEvent(t *testing.T, bus *eventspkg.Bus[eventspkg.Event], kind string, payload string) {
+	t.Helper()
+
+	raw, err := json.Marshal(kinds.ExtensionEventPayload{
+		Extension: "kpi",
+		Kind:      kind,
+		Payload:   json.RawMessage(payload),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	bus.Publish(context.Background(), eventspkg.Event{
+		RunID:   "run-metrics",
+		Kind:    eventspkg.EventKindExtensionEvent,
+		Payload: raw,
+	})
+}
+
+func containsAllLines(body string, lines []string) bool {
+	for _, line := range lines {
+		if !strings.Contains(body, line+"\n") {
+			return false
+		}
+	}
+	return true
+}
@@ -227,6 +227,8 @@ func prepareHostRuntime(
 	}
 	runtime.udsServer = servers.udsServer
 	runtime.httpServer = servers.httpServer
+	runManager.StartRetention(persistence.settings)
+	runManager.StartReaper(persistence.settings)
 	return runtime, nil
 }
 
@@ -246,6 +248,7 @@ func loadHostPersistence(ctx context.Context, currentHost *Host) (_ hostPersiste
 		}
 		err = errors.Join(err, db.Close())
 	}()
+	warnGlobalDBSchemaDrift(ctx, db)
 
 	settings, _, err := LoadRunLifecycleSettingsForHome(ctx, paths)
 	if err != nil {
@@ -284,6 +287,35 @@ func loadHostPersistence(ctx context.Context, currentHost *Host) (_ hostPersiste
 	}, nil
 }
 
+// warnGlobalDBSchemaDrift logs catalog schema objects that differ from the
+// applied migrations. Drift never blocks startup; it only explains later query
+// failures caused by manual hotfixes.
+func warnGlobalDBSchemaDrift(ctx context.Context, db *globaldb.GlobalDB) {
+	report, err := db.CheckSchemaDrift(ctx)
+	if err != nil {
+		slog.Warn("daemon global catalog schema drift check failed", "error", err)
+		return
+	}
+	for _, drift := range report.Drifts {
+		slog.Warn(
+			"daemon global catalog schema drift",
+			"kind",
+			drift.Kind,
+			"object",
+			drift.Object,
+			"name",
+			drift.Name,
+			"detail",
+			drift.Detail,
+			"migration_version",
+			report.Version,
+		)
+	}
+	if !report.Clean() {
+		slog.Warn("run `compozy db check` to review global catalog schema drift", "drifts", len(report.Drifts))
+	}
+}
+
 func buildHostHandlers(
 	currentHost *Host,
 	persistence hostPersistence,
@@ -316,6 +348,7 @@ func buildHostHandlers(
 		Runs:            runManager,
 		Sync:            newTransportSyncService(persistence.db, runManager),
 		Exec:            newTransportExecService(runManager),
+		Maintenance:     newTransportMaintenanceService(persistence.db),
 		WorkspaceEvents: runManager,
 	})
 }
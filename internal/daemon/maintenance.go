@@ -0,0 +1,170 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/compozy/compozy/internal/api/contract"
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+type transportMaintenanceService struct {
+	globalDB *globaldb.GlobalDB
+}
+
+var _ apicore.MaintenanceService = (*transportMaintenanceService)(nil)
+
+func newTransportMaintenanceService(globalDB *globaldb.GlobalDB) *transportMaintenanceService {
+	return &transportMaintenanceService{globalDB: globalDB}
+}
+
+// State returns the persisted global and per-workflow pauses.
+func (s *transportMaintenanceService) State(ctx context.Context) (apicore.MaintenanceState, error) {
+	if s == nil || s.globalDB == nil {
+		return apicore.MaintenanceState{}, maintenanceTransportUnavailable("maintenance state")
+	}
+	pauses, err := s.globalDB.ListMaintenancePauses(ctx)
+	if err != nil {
+		return apicore.MaintenanceState{}, err
+	}
+	return transportMaintenanceState(pauses), nil
+}
+
+// Pause records a global or per-workflow pause. Re-pausing replaces the reason.
+func (s *transportMaintenanceService) Pause(
+	ctx context.Context,
+	req apicore.MaintenanceRequest,
+) (apicore.MaintenanceState, error) {
+	if s == nil || s.globalDB == nil {
+		return apicore.MaintenanceState{}, maintenanceTransportUnavailable("maintenance pause")
+	}
+	workspaceID, err := s.resolveMaintenanceWorkspace(ctx, req)
+	if err != nil {
+		return apicore.MaintenanceState{}, err
+	}
+	if _, err := s.globalDB.PutMaintenancePause(ctx, globaldb.MaintenancePause{
+		WorkspaceID:  workspaceID,
+		WorkflowSlug: strings.TrimSpace(req.Workflow),
+		Reason:       strings.TrimSpace(req.Reason),
+	}); err != nil {
+		return apicore.MaintenanceState{}, err
+	}
+	return s.State(ctx)
+}
+
+// Resume removes a global or per-workflow pause. Resuming an unpaused scope is a no-op.
+func (s *transportMaintenanceService) Resume(
+	ctx context.Context,
+	req apicore.MaintenanceRequest,
+) (apicore.MaintenanceState, error) {
+	if s == nil || s.globalDB == nil {
+		return apicore.MaintenanceState{}, maintenanceTransportUnavailable("maintenance resume")
+	}
+	workspaceID, err := s.resolveMaintenanceWorkspace(ctx, req)
+	if err != nil {
+		return apicore.MaintenanceState{}, err
+	}
+	if _, err := s.globalDB.DeleteMaintenancePause(ctx, workspaceID, req.Workflow); err != nil {
+		return apicore.MaintenanceState{}, err
+	}
+	return s.State(ctx)
+}
+
+func (s *transportMaintenanceService) resolveMaintenanceWorkspace(
+	ctx context.Context,
+	req apicore.MaintenanceRequest,
+) (string, error) {
+	if strings.TrimSpace(req.Workflow) == "" {
+		if strings.TrimSpace(req.Workspace) != "" {
+			return "", apicore.NewProblem(
+				http.StatusUnprocessableEntity,
+				"workflow_required",
+				"workflow is required when a workspace is given",
+				nil,
+				nil,
+			)
+		}
+		return "", nil
+	}
+	workspaceRow, err := s.globalDB.Get(ctx, strings.TrimSpace(req.Workspace))
+	if err != nil {
+		return "", err
+	}
+	return workspaceRow.ID, nil
+}
+
+// checkMaintenance rejects a new run while the daemon or its workflow is
+// paused. Child runs belong to an in-flight parent and are always allowed.
+func (m *RunManager) checkMaintenance(ctx context.Context, spec startRunSpec) error {
+	if m.globalDB == nil || strings.TrimSpace(spec.parentRunID) != "" {
+		return nil
+	}
+	pauses, err := m.globalDB.ListMaintenancePauses(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID := strings.TrimSpace(spec.workspace.ID)
+	slug := strings.TrimSpace(spec.workflowSlug)
+	for _, pause := range pauses {
+		if pause.Global() || (slug != "" && pause.WorkspaceID == workspaceID && pause.WorkflowSlug == slug) {
+			return maintenanceModeProblem(pause)
+		}
+	}
+	return nil
+}
+
+func maintenanceModeProblem(pause globaldb.MaintenancePause) error {
+	details := map[string]any{
+		"scope":     "global",
+		"paused_at": pause.PausedAt,
+	}
+	message := "daemon is in maintenance mode; new runs are paused"
+	if !pause.Global() {
+		details["scope"] = "workflow"
+		details["workspace_id"] = pause.WorkspaceID
+		details["workflow"] = pause.WorkflowSlug
+		message = "workflow " + pause.WorkflowSlug + " is in maintenance mode; new runs are paused"
+	}
+	if pause.Reason != "" {
+		details["reason"] = pause.Reason
+	}
+	return apicore.NewProblem(
+		http.StatusServiceUnavailable,
+		string(contract.CodeMaintenanceMode),
+		message,
+		details,
+		nil,
+	)
+}
+
+func transportMaintenanceState(pauses []globaldb.MaintenancePause) apicore.MaintenanceState {
+	state := apicore.MaintenanceState{Workflows: make([]apicore.MaintenanceWorkflow, 0, len(pauses))}
+	for _, pause := range pauses {
+		if pause.Global() {
+			pausedAt := pause.PausedAt
+			state.Paused = true
+			state.Reason = pause.Reason
+			state.PausedAt = &pausedAt
+			continue
+		}
+		state.Workflows = append(state.Workflows, apicore.MaintenanceWorkflow{
+			WorkspaceID:  pause.WorkspaceID,
+			WorkflowSlug: pause.WorkflowSlug,
+			Reason:       pause.Reason,
+			PausedAt:     pause.PausedAt,
+		})
+	}
+	return state
+}
+
+func maintenanceTransportUnavailable(action string) error {
+	return apicore.NewProblem(
+		http.StatusServiceUnavailable,
+		"maintenance_service_unavailable",
+		action+" is not available in this daemon build",
+		nil,
+		nil,
+	)
+}
@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/compozy/compozy/internal/core/model"
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+func TestRunManagerMaintenancePauseBlocksNewRuns(t *testing.T) {
+	t.Run("Should reject new runs while paused and let in-flight runs finish", func(t *testing.T) {
+		release := make(chan struct{})
+		env := newRunManagerTestEnv(t, runManagerTestDeps{
+			prepare: func(context.Context, *model.RuntimeConfig, model.RunScope) (*model.SolvePreparation, error) {
+				return &model.SolvePreparation{}, nil
+			},
+			execute: func(ctx context.Context, _ *model.SolvePreparation, _ *model.RuntimeConfig) error {
+				select {
+				case <-release:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		})
+		service := newTransportMaintenanceService(env.globalDB)
+		ctx := context.Background()
+
+		inFlight := env.startTaskRun(t, "maintenance-in-flight", nil)
+		state, err := service.Pause(ctx, apicore.MaintenanceRequest{Reason: "upgrade"})
+		if err != nil {
+			t.Fatalf("Pause(global) error = %v", err)
+		}
+		if !state.Paused || state.Reason != "upgrade" || state.PausedAt == nil {
+			t.Fatalf("Pause(global) state = %#v, want paused with reason", state)
+		}
+
+		assertMaintenanceProblem(t, env, "maintenance-blocked", "global")
+
+		close(release)
+		row := waitForRun(t, env.globalDB, inFlight.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+		if row.Status != runStatusCompleted {
+			t.Fatalf("in-flight run status = %q, want %q", row.Status, runStatusCompleted)
+		}
+
+		if _, err := service.Resume(ctx, apicore.MaintenanceRequest{}); err != nil {
+			t.Fatalf("Resume(global) error = %v", err)
+		}
+		resumed := env.startTaskRun(t, "maintenance-resumed", nil)
+		waitForRun(t, env.globalDB, resumed.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+	})
+
+	t.Run("Should pause only the named workflow", func(t *testing.T) {
+		env := newRunManagerTestEnv(t, runManagerTestDeps{})
+		service := newTransportMaintenanceService(env.globalDB)
+		ctx := context.Background()
+		if _, err := env.globalDB.ResolveOrRegister(ctx, env.workspaceRoot); err != nil {
+			t.Fatalf("ResolveOrRegister(%q) error = %v", env.workspaceRoot, err)
+		}
+
+		state, err := service.Pause(ctx, apicore.MaintenanceRequest{
+			Workspace: env.workspaceRoot,
+			Workflow:  env.workflowSlug,
+		})
+		if err != nil {
+			t.Fatalf("Pause(workflow) error = %v", err)
+		}
+		if state.Paused || len(state.Workflows) != 1 || state.Workflows[0].WorkflowSlug != env.workflowSlug {
+			t.Fatalf("Pause(workflow) state = %#v, want one workflow pause", state)
+		}
+
+		assertMaintenanceProblem(t, env, "maintenance-workflow-blocked", "workflow")
+		execRun, err := env.manager.StartExecRun(ctx, apicore.ExecRequest{
+			WorkspacePath:    env.workspaceRoot,
+			Prompt:           "hello",
+			PresentationMode: defaultPresentationMode,
+		})
+		if err != nil {
+			t.Fatalf("StartExecRun() while workflow paused error = %v", err)
+		}
+		waitForRun(t, env.globalDB, execRun.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+
+		state, err = service.Resume(ctx, apicore.MaintenanceRequest{
+			Workspace: env.workspaceRoot,
+			Workflow:  env.workflowSlug,
+		})
+		if err != nil {
+			t.Fatalf("Resume(workflow) error = %v", err)
+		}
+		if len(state.Workflows) != 0 {
+			t.Fatalf("Resume(workflow) state = %#v, want no pauses", state)
+		}
+	})
+
+	t.Run("Should require a workflow when a workspace is given", func(t *testing.T) {
+		env := newRunManagerTestEnv(t, runManagerTestDeps{})
+		service := newTransportMaintenanceService(env.globalDB)
+
+		_, err := service.Pause(context.Background(), apicore.MaintenanceRequest{Workspace: env.workspaceRoot})
+		assertProblemStatus(t, err, http.StatusUnprocessableEntity)
+	})
+}
+
+func assertMaintenanceProblem(t *testing.T, env *runManagerTestEnv, runID string, scope string) {
+	t.Helper()
+
+	_, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, env.workflowSlug, apicore.TaskRunRequest{
+		Workspace:        env.workspaceRoot,
+		PresentationMode: defaultPresentationMode,
+		RuntimeOverrides: rawJSON(t, `{"run_id":"`+runID+`"}`),
+	})
+	var problem *apicore.Problem
+	if !errors.As(err, &problem) {
+		t.Fatalf("StartTaskRun(%q) error = %v, want maintenance problem", runID, err)
+	}
+	if problem.Status != http.StatusServiceUnavailable || problem.Code != "maintenance_mode" {
+		t.Fatalf("problem = status:%d code:%q, want 503 maintenance_mode", problem.Status, problem.Code)
+	}
+	if got := problem.Details["scope"]; got != scope {
+		t.Fatalf("problem scope = %v, want %q", got, scope)
+	}
+	if _, err := env.globalDB.GetRun(context.Background(), runID); !errors.Is(err, globaldb.ErrRunNotFound) {
+		t.Fatalf("GetRun(%q) error = %v, want ErrRunNotFound", runID, err)
+	}
+}
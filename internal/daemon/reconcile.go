@@ -23,6 +23,7 @@ const (
 	defaultKeepMax              = 200
 	defaultShutdownDrainTimeout = 30 * time.Second
 	defaultRunCloseTimeout      = time.Second
+	defaultReapInterval         = time.Minute
 	sqliteHeader                = "SQLite format 3\x00"
 )
 
@@ -31,7 +32,11 @@ const (
 type RunLifecycleSettings struct {
 	KeepTerminalDays     int
 	KeepMax              int
+	PurgeInterval        time.Duration
+	MaxRunDuration       time.Duration
+	ReapInterval         time.Duration
 	ShutdownDrainTimeout time.Duration
+	ArchiveDir           string
 	RunsDir              string
 	WorktreesRoot        string
 }
@@ -94,6 +99,7 @@ func resolveRunLifecycleSettings(cfg workspacecfg.RunsConfig) (RunLifecycleSetti
 		KeepTerminalDays:     defaultKeepTerminalDays,
 		KeepMax:              defaultKeepMax,
 		ShutdownDrainTimeout: defaultShutdownDrainTimeout,
+		ReapInterval:         defaultReapInterval,
 	}
 
 	if cfg.KeepTerminalDays != nil {
@@ -109,6 +115,30 @@ func resolveRunLifecycleSettings(cfg workspacecfg.RunsConfig) (RunLifecycleSetti
 		}
 		settings.ShutdownDrainTimeout = duration
 	}
+	if cfg.PurgeInterval != nil {
+		interval, err := time.ParseDuration(strings.TrimSpace(*cfg.PurgeInterval))
+		if err != nil {
+			return RunLifecycleSettings{}, fmt.Errorf("daemon: parse runs.purge_interval: %w", err)
+		}
+		settings.PurgeInterval = interval
+	}
+	if cfg.MaxRunDuration != nil {
+		duration, err := time.ParseDuration(strings.TrimSpace(*cfg.MaxRunDuration))
+		if err != nil {
+			return RunLifecycleSettings{}, fmt.Errorf("daemon: parse runs.max_run_duration: %w", err)
+		}
+		settings.MaxRunDuration = duration
+	}
+	if cfg.ReapInterval != nil {
+		interval, err := time.ParseDuration(strings.TrimSpace(*cfg.ReapInterval))
+		if err != nil {
+			return RunLifecycleSettings{}, fmt.Errorf("daemon: parse runs.reap_interval: %w", err)
+		}
+		settings.ReapInterval = interval
+	}
+	if cfg.ArchiveDir != nil {
+		settings.ArchiveDir = strings.TrimSpace(*cfg.ArchiveDir)
+	}
 	return settings, nil
 }
 
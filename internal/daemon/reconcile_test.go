@@ -163,7 +163,7 @@ func TestLoadRunLifecycleSettingsPopulatesWorktreesRoot(t *testing.T) {
 		}
 		if err := os.WriteFile(
 			paths.ConfigFile,
-			[]byte("[runs]\nkeep_max = 17\n"),
+			[]byte("[runs]\nkeep_max = 17\npurge_interval = \"6h\"\n"),
 			0o600,
 		); err != nil {
 			t.Fatalf("write captured config: %v", err)
@@ -182,6 +182,9 @@ func TestLoadRunLifecycleSettingsPopulatesWorktreesRoot(t *testing.T) {
 		if settings.KeepMax != 17 {
 			t.Fatalf("KeepMax = %d, want 17 from captured config", settings.KeepMax)
 		}
+		if settings.PurgeInterval != 6*time.Hour {
+			t.Fatalf("PurgeInterval = %s, want 6h from captured config", settings.PurgeInterval)
+		}
 		if configPath != paths.ConfigFile {
 			t.Fatalf("config path = %q, want %q", configPath, paths.ConfigFile)
 		}
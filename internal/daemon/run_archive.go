@@ -0,0 +1,129 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/compozy/compozy/internal/store"
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+// runArchiveDirName holds one <run_id>.json summary and, when the run had a
+// database, one <run_id>.db snapshot per archived run.
+const runArchiveDirName = "runs"
+
+// runArchiveRecord is the JSON summary kept for each purged run.
+type runArchiveRecord struct {
+	RunID            string            `json:"run_id"`
+	WorkspaceID      string            `json:"workspace_id"`
+	WorkflowID       *string           `json:"workflow_id,omitempty"`
+	ParentRunID      string            `json:"parent_run_id,omitempty"`
+	Mode             string            `json:"mode"`
+	Status           string            `json:"status"`
+	PresentationMode string            `json:"presentation_mode,omitempty"`
+	StartedAt        time.Time         `json:"started_at"`
+	EndedAt          *time.Time        `json:"ended_at,omitempty"`
+	ErrorText        string            `json:"error_text,omitempty"`
+	RequestID        string            `json:"request_id,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	RunDB            string            `json:"run_db,omitempty"`
+	ArchivedAt       time.Time         `json:"archived_at"`
+}
+
+// archiveRun copies the run database and writes the run summary under
+// archiveDir. Purge calls it before removing anything, so a failure leaves the
+// run in place for the next pass. Both files are keyed by run id, so archiving
+// the same run again replaces its summary instead of adding a second one.
+func (m *RunManager) archiveRun(ctx context.Context, run *globaldb.Run, archiveDir string) error {
+	archiveRoot := strings.TrimSpace(archiveDir)
+	if archiveRoot == "" {
+		return errors.New("archive directory is required")
+	}
+	runsDir := filepath.Join(archiveRoot, runArchiveDirName)
+	if err := os.MkdirAll(runsDir, 0o755); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+
+	runDBArchivePath, err := m.archiveRunDB(ctx, run.RunID, runsDir)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(runArchiveRecord{
+		RunID:            run.RunID,
+		WorkspaceID:      run.WorkspaceID,
+		WorkflowID:       run.WorkflowID,
+		ParentRunID:      run.ParentRunID,
+		Mode:             run.Mode,
+		Status:           run.Status,
+		PresentationMode: run.PresentationMode,
+		StartedAt:        run.StartedAt,
+		EndedAt:          run.EndedAt,
+		ErrorText:        run.ErrorText,
+		RequestID:        run.RequestID,
+		Labels:           run.Labels,
+		RunDB:            runDBArchivePath,
+		ArchivedAt:       m.now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode archive record: %w", err)
+	}
+	if err := writeArchiveFile(filepath.Join(runsDir, run.RunID+".json"), append(payload, '\n')); err != nil {
+		return fmt.Errorf("write archive record: %w", err)
+	}
+	return nil
+}
+
+// writeArchiveFile replaces path through a temporary file in the same
+// directory so readers never see a partial summary.
+func writeArchiveFile(path string, payload []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := file.Name()
+	if _, err := file.Write(payload); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tempPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+// archiveRunDB snapshots run.db into <runsDir>/<run_id>.db and returns the
+// snapshot path. Runs without a database archive only their summary, and a
+// snapshot left by an earlier interrupted pass is reused.
+func (m *RunManager) archiveRunDB(ctx context.Context, runID string, runsDir string) (string, error) {
+	source := m.runArtifacts(runID).RunDBPath
+	if _, err := os.Stat(source); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("stat run database: %w", err)
+	}
+
+	target := filepath.Join(runsDir, runID+".db")
+	if _, err := os.Stat(target); err == nil {
+		return target, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("stat archived run database: %w", err)
+	}
+	if err := store.BackupSQLiteFile(ctx, source, target); err != nil {
+		return "", fmt.Errorf("copy run database: %w", err)
+	}
+	return target, nil
+}
@@ -0,0 +1,193 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compozy/compozy/internal/store/globaldb"
+	"github.com/compozy/compozy/internal/store/rundb"
+	eventspkg "github.com/compozy/compozy/pkg/compozy/events"
+	"github.com/compozy/compozy/pkg/compozy/events/kinds"
+)
+
+func TestRunManagerPurgeArchivesRunsBeforeRemovingThem(t *testing.T) {
+	env := newRunManagerTestEnv(t, runManagerTestDeps{})
+
+	workspace, err := env.globalDB.ResolveOrRegister(context.Background(), env.workspaceRoot)
+	if err != nil {
+		t.Fatalf("ResolveOrRegister(%q) error = %v", env.workspaceRoot, err)
+	}
+	endedAt := time.Now().UTC().AddDate(0, 0, -30)
+	seedTerminalRunForPurge(t, env.manager, env.globalDB, workspace.ID, "archive-with-db", runStatusFailed, endedAt)
+	seedTerminalRunForPurge(t, env.manager, env.globalDB, workspace.ID, "archive-no-db", runStatusCompleted, endedAt)
+
+	runDB, err := rundb.Open(context.Background(), env.manager.runArtifacts("archive-with-db").RunDBPath)
+	if err != nil {
+		t.Fatalf("rundb.Open(archive-with-db) error = %v", err)
+	}
+	if err := runDB.Close(); err != nil {
+		t.Fatalf("close run db: %v", err)
+	}
+
+	archiveDir := filepath.Join(t.TempDir(), "archive")
+	settings := RunLifecycleSettings{KeepTerminalDays: 14, KeepMax: 100, ArchiveDir: archiveDir}
+	result, err := env.manager.Purge(context.Background(), settings)
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+
+	t.Run("Should archive every purged run", func(t *testing.T) {
+		want := []string{"archive-no-db", "archive-with-db"}
+		if !equalStrings(slices.Sorted(slices.Values(result.ArchivedRunIDs)), want) ||
+			!equalStrings(slices.Sorted(slices.Values(result.PurgedRunIDs)), want) {
+			t.Fatalf("archived = %v, purged = %v, want both %v", result.ArchivedRunIDs, result.PurgedRunIDs, want)
+		}
+		for _, runID := range want {
+			if _, err := env.globalDB.GetRun(context.Background(), runID); !errors.Is(err, globaldb.ErrRunNotFound) {
+				t.Fatalf("GetRun(%q) error = %v, want ErrRunNotFound", runID, err)
+			}
+		}
+	})
+
+	t.Run("Should write one summary per archived run", func(t *testing.T) {
+		withDB := readRunArchiveRecord(t, archiveDir, "archive-with-db")
+		if withDB.Status != runStatusFailed || withDB.WorkspaceID != workspace.ID || withDB.EndedAt == nil {
+			t.Fatalf("unexpected archive record: %#v", withDB)
+		}
+		if withDB.RunDB != filepath.Join(archiveDir, runArchiveDirName, "archive-with-db.db") {
+			t.Fatalf("archive record run_db = %q", withDB.RunDB)
+		}
+		if _, err := os.Stat(withDB.RunDB); err != nil {
+			t.Fatalf("stat archived run db: %v", err)
+		}
+		if noDB := readRunArchiveRecord(t, archiveDir, "archive-no-db"); noDB.RunDB != "" {
+			t.Fatalf("archive-no-db record = %#v, want summary without run_db", noDB)
+		}
+	})
+
+	t.Run("Should replace the summary when a run is archived again", func(t *testing.T) {
+		run := globaldb.Run{RunID: "archive-retried", WorkspaceID: workspace.ID, Status: runStatusFailed}
+		for range 2 {
+			if err := env.manager.archiveRun(context.Background(), &run, archiveDir); err != nil {
+				t.Fatalf("archiveRun() error = %v", err)
+			}
+		}
+		entries, err := os.ReadDir(filepath.Join(archiveDir, runArchiveDirName))
+		if err != nil {
+			t.Fatalf("read archive directory: %v", err)
+		}
+		summaries := 0
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), "archive-retried") {
+				summaries++
+			}
+		}
+		if summaries != 1 {
+			t.Fatalf("archive entries = %d for archive-retried, want 1", summaries)
+		}
+		readRunArchiveRecord(t, archiveDir, "archive-retried")
+	})
+
+	t.Run("Should keep the run when archiving fails", func(t *testing.T) {
+		seedTerminalRunForPurge(t, env.manager, env.globalDB, workspace.ID, "archive-blocked", runStatusFailed, endedAt)
+		blocked := filepath.Join(t.TempDir(), "not-a-dir")
+		if err := os.WriteFile(blocked, []byte("x"), 0o600); err != nil {
+			t.Fatalf("write blocking file: %v", err)
+		}
+
+		settings.ArchiveDir = blocked
+		if _, err := env.manager.Purge(context.Background(), settings); err == nil {
+			t.Fatal("Purge() error = nil, want archive failure")
+		}
+		if _, err := env.globalDB.GetRun(context.Background(), "archive-blocked"); err != nil {
+			t.Fatalf("GetRun(archive-blocked) error = %v, want row kept", err)
+		}
+		if _, err := os.Stat(env.manager.runArtifacts("archive-blocked").RunDir); err != nil {
+			t.Fatalf("stat archive-blocked run dir: %v", err)
+		}
+	})
+
+	t.Run("Should count archived runs from scheduled purges", func(t *testing.T) {
+		env.manager.recordRetentionPass(result, nil)
+		if got := env.manager.RetentionArchivedTotal(); got != 2 {
+			t.Fatalf("RetentionArchivedTotal() = %d, want 2", got)
+		}
+	})
+}
+
+func TestRunManagerPurgeKeepsWorktreesWhenArchivingFails(t *testing.T) {
+	requireGitForTaskMulti(t)
+	env := newRunManagerTestEnv(t, runManagerTestDeps{})
+	writeFileForTest(t, filepath.Join(env.workspaceRoot, "README.md"), "seed\n")
+	commitTaskMultiGitWorkspace(t, env.workspaceRoot)
+
+	workspace, err := env.globalDB.ResolveOrRegister(context.Background(), env.workspaceRoot)
+	if err != nil {
+		t.Fatalf("ResolveOrRegister(%q) error = %v", env.workspaceRoot, err)
+	}
+	runID := "archive-blocked-worktrees"
+	seedTerminalRunForPurge(
+		t,
+		env.manager,
+		env.globalDB,
+		workspace.ID,
+		runID,
+		runStatusCompleted,
+		time.Now().UTC().Add(-time.Hour),
+	)
+	allocation := allocatePurgeTaskWorktree(t, env, runID, "alpha", 1)
+	appendPurgeRunEvent(
+		t,
+		env.manager,
+		runID,
+		eventspkg.EventKindTaskRunMultipleChildCompleted,
+		kinds.TaskRunMultiplePayload{
+			RunID:          runID,
+			Slug:           "alpha",
+			Index:          0,
+			WorktreePath:   allocation.Path,
+			BaseBranch:     allocation.BaseBranch,
+			BaseCommit:     allocation.BaseCommit,
+			WorktreeStatus: allocation.WorktreeStatus,
+		},
+	)
+	blocked := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocked, []byte("x"), 0o600); err != nil {
+		t.Fatalf("write blocking file: %v", err)
+	}
+
+	_, err = env.manager.Purge(context.Background(), RunLifecycleSettings{ArchiveDir: blocked})
+	if err == nil {
+		t.Fatal("Purge() error = nil, want archive failure")
+	}
+	if _, err := os.Stat(allocation.Path); err != nil {
+		t.Fatalf("stat task worktree after failed archive: %v", err)
+	}
+	if _, err := env.globalDB.GetRun(context.Background(), runID); err != nil {
+		t.Fatalf("GetRun(%q) error = %v, want row kept", runID, err)
+	}
+}
+
+func readRunArchiveRecord(t *testing.T, archiveDir string, runID string) runArchiveRecord {
+	t.Helper()
+
+	payload, err := os.ReadFile(filepath.Join(archiveDir, runArchiveDirName, runID+".json"))
+	if err != nil {
+		t.Fatalf("read archive record %q: %v", runID, err)
+	}
+	var record runArchiveRecord
+	if err := json.Unmarshal(payload, &record); err != nil {
+		t.Fatalf("decode archive record %q: %v", runID, err)
+	}
+	if record.RunID != runID {
+		t.Fatalf("archive record run_id = %q, want %q", record.RunID, runID)
+	}
+	return record
+}
@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+)
+
+// pendingDedupeStart marks an in-flight start for one dedupe key so an
+// identical concurrent start waits for it instead of racing a duplicate.
+type pendingDedupeStart struct {
+	done chan struct{}
+}
+
+// runDedupeKey hashes the run mode, workspace, workflow, and request. The
+// request is round-tripped through JSON so key order does not matter.
+func runDedupeKey(mode string, workspaceID string, workflowSlug string, request any) (string, error) {
+	raw, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("daemon: encode dedupe request: %w", err)
+	}
+	var canonical any
+	if err := json.Unmarshal(raw, &canonical); err != nil {
+		return "", fmt.Errorf("daemon: canonicalize dedupe request: %w", err)
+	}
+	normalized, err := json.Marshal(struct {
+		Mode        string `json:"mode"`
+		WorkspaceID string `json:"workspace_id"`
+		Workflow    string `json:"workflow"`
+		Request     any    `json:"request"`
+	}{
+		Mode:        mode,
+		WorkspaceID: strings.TrimSpace(workspaceID),
+		Workflow:    strings.TrimSpace(workflowSlug),
+		Request:     canonical,
+	})
+	if err != nil {
+		return "", fmt.Errorf("daemon: encode dedupe key: %w", err)
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// joinDuplicateRun returns the active run an identical top-level start should
+// join when runs.dedupe_window is set. Otherwise it reserves the dedupe key
+// and returns a release func the caller must invoke once the start settles.
+func (m *RunManager) joinDuplicateRun(ctx context.Context, spec startRunSpec) (apicore.Run, bool, func(), error) {
+	noop := func() {}
+	key := strings.TrimSpace(spec.dedupeKey)
+	if key == "" || strings.TrimSpace(spec.parentRunID) != "" {
+		return apicore.Run{}, false, noop, nil
+	}
+	projectCfg, err := m.loadProjectConfig(ctx, spec.workspace.RootDir)
+	if err != nil {
+		return apicore.Run{}, false, nil, err
+	}
+	window := projectCfg.Runs.EffectiveDedupeWindow()
+	if window <= 0 {
+		return apicore.Run{}, false, noop, nil
+	}
+
+	for {
+		m.mu.Lock()
+		if runID := m.activeDuplicateLocked(key, window); runID != "" {
+			m.mu.Unlock()
+			run, err := m.Get(ctx, runID)
+			if err != nil {
+				return apicore.Run{}, false, nil, err
+			}
+			slog.Info(
+				"daemon: joined duplicate run start",
+				"run_id", runID,
+				"mode", spec.mode,
+				"workflow", spec.workflowSlug,
+			)
+			return run, true, noop, nil
+		}
+		pending := m.dedupeStarts[key]
+		if pending == nil {
+			pending = &pendingDedupeStart{done: make(chan struct{})}
+			if m.dedupeStarts == nil {
+				m.dedupeStarts = make(map[string]*pendingDedupeStart)
+			}
+			m.dedupeStarts[key] = pending
+			m.mu.Unlock()
+			var once sync.Once
+			return apicore.Run{}, false, func() {
+				once.Do(func() {
+					m.mu.Lock()
+					delete(m.dedupeStarts, key)
+					m.mu.Unlock()
+					close(pending.done)
+				})
+			}, nil
+		}
+		m.mu.Unlock()
+
+		select {
+		case <-pending.done:
+		case <-ctx.Done():
+			return apicore.Run{}, false, nil, ctx.Err()
+		case <-m.lifecycleCtx.Done():
+			return apicore.Run{}, false, nil, context.Cause(m.lifecycleCtx)
+		}
+	}
+}
+
+func (m *RunManager) activeDuplicateLocked(key string, window time.Duration) string {
+	cutoff := m.now().Add(-window)
+	for runID, active := range m.active {
+		if active == nil || active.dedupeKey != key || active.startedAt.Before(cutoff) {
+			continue
+		}
+		if active.cancelWasRequested() {
+			continue
+		}
+		return runID
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/compozy/compozy/internal/core/model"
+	workspacecfg "github.com/compozy/compozy/internal/core/workspace"
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+func TestRunManagerDedupeWindowJoinsIdenticalStarts(t *testing.T) {
+	t.Run("Should return the active run for identical concurrent starts", func(t *testing.T) {
+		release := make(chan struct{})
+		env := newDedupeTestEnv(t, "10m", release)
+		req := apicore.TaskRunRequest{Workspace: env.workspaceRoot, PresentationMode: defaultPresentationMode}
+
+		const starts = 3
+		runIDs := make([]string, starts)
+		errs := make([]error, starts)
+		var wg sync.WaitGroup
+		for idx := range starts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				run, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, env.workflowSlug, req)
+				runIDs[idx], errs[idx] = run.RunID, err
+			}()
+		}
+		wg.Wait()
+		for idx := range starts {
+			if errs[idx] != nil {
+				t.Fatalf("StartTaskRun(%d) error = %v", idx, errs[idx])
+			}
+			if runIDs[idx] != runIDs[0] {
+				t.Fatalf("StartTaskRun run ids = %v, want one shared run", runIDs)
+			}
+		}
+
+		distinct, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, env.workflowSlug,
+			apicore.TaskRunRequest{
+				Workspace:        env.workspaceRoot,
+				PresentationMode: defaultPresentationMode,
+				RuntimeOverrides: rawJSON(t, `{"run_id":"dedupe-distinct"}`),
+			})
+		if err != nil {
+			t.Fatalf("StartTaskRun(distinct) error = %v", err)
+		}
+		if distinct.RunID == runIDs[0] {
+			t.Fatalf("distinct request joined run %q, want a new run", runIDs[0])
+		}
+
+		close(release)
+		for _, runID := range []string{runIDs[0], distinct.RunID} {
+			waitForRun(t, env.globalDB, runID, func(row globaldb.Run) bool {
+				return isTerminalRunStatus(row.Status)
+			})
+		}
+	})
+
+	t.Run("Should start separate runs when the window is disabled", func(t *testing.T) {
+		release := make(chan struct{})
+		env := newDedupeTestEnv(t, "0s", release)
+		req := apicore.TaskRunRequest{Workspace: env.workspaceRoot, PresentationMode: defaultPresentationMode}
+
+		first, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, env.workflowSlug, req)
+		if err != nil {
+			t.Fatalf("StartTaskRun(first) error = %v", err)
+		}
+		second, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, env.workflowSlug, req)
+		if err != nil {
+			t.Fatalf("StartTaskRun(second) error = %v", err)
+		}
+		if first.RunID == second.RunID {
+			t.Fatalf("run ids = %q/%q, want separate runs", first.RunID, second.RunID)
+		}
+
+		close(release)
+		for _, runID := range []string{first.RunID, second.RunID} {
+			waitForRun(t, env.globalDB, runID, func(row globaldb.Run) bool {
+				return isTerminalRunStatus(row.Status)
+			})
+		}
+	})
+}
+
+func TestRunDedupeKeyIgnoresJSONFieldOrder(t *testing.T) {
+	t.Parallel()
+
+	first, err := runDedupeKey(runModeTask, "ws-1", "demo", apicore.TaskRunRequest{
+		RuntimeOverrides: []byte(`{"model":"m","ide":"codex"}`),
+	})
+	if err != nil {
+		t.Fatalf("runDedupeKey(first) error = %v", err)
+	}
+	second, err := runDedupeKey(runModeTask, "ws-1", "demo", apicore.TaskRunRequest{
+		RuntimeOverrides: []byte(`{ "ide": "codex", "model": "m" }`),
+	})
+	if err != nil {
+		t.Fatalf("runDedupeKey(second) error = %v", err)
+	}
+	if first != second {
+		t.Fatalf("dedupe keys differ for reordered overrides: %q vs %q", first, second)
+	}
+	other, err := runDedupeKey(runModeTask, "ws-1", "other", apicore.TaskRunRequest{
+		RuntimeOverrides: []byte(`{"model":"m","ide":"codex"}`),
+	})
+	if err != nil {
+		t.Fatalf("runDedupeKey(other) error = %v", err)
+	}
+	if other == first {
+		t.Fatal("dedupe key ignores the workflow slug")
+	}
+}
+
+func newDedupeTestEnv(t *testing.T, window string, release <-chan struct{}) *runManagerTestEnv {
+	t.Helper()
+
+	return newRunManagerTestEnv(t, runManagerTestDeps{
+		loadProjectConfig: func(context.Context, string) (workspacecfg.ProjectConfig, error) {
+			return workspacecfg.ProjectConfig{
+				Runs: workspacecfg.RunsConfig{DedupeWindow: &window},
+			}, nil
+		},
+		prepare: func(context.Context, *model.RuntimeConfig, model.RunScope) (*model.SolvePreparation, error) {
+			return &model.SolvePreparation{}, nil
+		},
+		execute: func(ctx context.Context, _ *model.SolvePreparation, _ *model.RuntimeConfig) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
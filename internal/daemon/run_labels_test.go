@@ -0,0 +1,165 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"testing"
+	"time"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/compozy/compozy/internal/core/model"
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+func TestRunManagerPersistsAndPropagatesRunLabels(t *testing.T) {
+	t.Run("Should store request labels and carry them on the run context", func(t *testing.T) {
+		labels := map[string]string{"team": "core", "env": "prod"}
+		executed := make(chan map[string]string, 1)
+		env := newRunManagerTestEnv(t, runManagerTestDeps{
+			prepare: func(context.Context, *model.RuntimeConfig, model.RunScope) (*model.SolvePreparation, error) {
+				return &model.SolvePreparation{}, nil
+			},
+			execute: func(ctx context.Context, _ *model.SolvePreparation, _ *model.RuntimeConfig) error {
+				executed <- apicore.RunLabelsFromContext(ctx)
+				return nil
+			},
+		})
+
+		ctx := apicore.WithRunLabels(context.Background(), labels)
+		run, err := env.manager.StartTaskRun(ctx, env.workspaceRoot, env.workflowSlug, apicore.TaskRunRequest{
+			Workspace:        env.workspaceRoot,
+			PresentationMode: defaultPresentationMode,
+			RuntimeOverrides: rawJSON(t, `{"run_id":"labels-run"}`),
+			Labels:           labels,
+		})
+		if err != nil {
+			t.Fatalf("StartTaskRun() error = %v", err)
+		}
+		if !maps.Equal(run.Labels, labels) {
+			t.Fatalf("started run labels = %#v, want %#v", run.Labels, labels)
+		}
+		if got := <-executed; !maps.Equal(got, labels) {
+			t.Fatalf("run context labels = %#v, want %#v", got, labels)
+		}
+		row := waitForRun(t, env.globalDB, run.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+		if !maps.Equal(row.Labels, labels) {
+			t.Fatalf("stored labels = %#v, want %#v", row.Labels, labels)
+		}
+
+		listed, err := env.manager.List(context.Background(), apicore.RunListQuery{
+			Labels: map[string]string{"team": "core"},
+		})
+		if err != nil {
+			t.Fatalf("List(labels) error = %v", err)
+		}
+		if len(listed) != 1 || listed[0].RunID != run.RunID {
+			t.Fatalf("List(labels) = %#v, want only %q", listed, run.RunID)
+		}
+	})
+}
+
+func TestRunManagerResumedExecRunKeepsLabelsUnlessReplaced(t *testing.T) {
+	existing := map[string]string{"team": "core"}
+	for _, tc := range []struct {
+		name    string
+		runID   string
+		request map[string]string
+		want    map[string]string
+	}{
+		{
+			name:  "Should keep stored labels when the request has none",
+			runID: "exec-resume-keep-labels",
+			want:  existing,
+		},
+		{
+			name:    "Should replace stored labels with the request labels",
+			runID:   "exec-resume-replace-labels",
+			request: map[string]string{"team": "infra"},
+			want:    map[string]string{"team": "infra"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			scopeErr := errors.New("scope unavailable")
+			env := newRunManagerTestEnv(t, runManagerTestDeps{
+				openRunScope: func(context.Context, *model.RuntimeConfig, model.OpenRunScopeOptions) (model.RunScope, error) {
+					return nil, scopeErr
+				},
+			})
+			createdAt := time.Date(2026, 5, 13, 15, 5, 0, 0, time.UTC)
+			writePersistedExecRun(t, env.workspaceRoot, tc.runID, createdAt)
+			workspace, err := env.globalDB.ResolveOrRegister(context.Background(), env.workspaceRoot)
+			if err != nil {
+				t.Fatalf("ResolveOrRegister() error = %v", err)
+			}
+			if _, err := env.globalDB.PutRun(context.Background(), globaldb.Run{
+				RunID:            tc.runID,
+				WorkspaceID:      workspace.ID,
+				Mode:             runModeExec,
+				Status:           runStatusCompleted,
+				PresentationMode: defaultPresentationMode,
+				StartedAt:        createdAt,
+				Labels:           existing,
+			}); err != nil {
+				t.Fatalf("PutRun(existing exec) error = %v", err)
+			}
+
+			ctx := apicore.WithRunLabels(context.Background(), tc.request)
+			_, err = env.manager.StartExecRun(ctx, apicore.ExecRequest{
+				WorkspacePath:    env.workspaceRoot,
+				Prompt:           "daemon exec prompt",
+				PresentationMode: defaultPresentationMode,
+				RuntimeOverrides: rawJSON(t, `{"run_id":"`+tc.runID+`"}`),
+			})
+			if !errors.Is(err, scopeErr) {
+				t.Fatalf("StartExecRun() error = %v, want %v", err, scopeErr)
+			}
+
+			row := waitForRun(t, env.globalDB, tc.runID, func(row globaldb.Run) bool {
+				return row.Status == runStatusFailed
+			})
+			if !maps.Equal(row.Labels, tc.want) {
+				t.Fatalf("stored labels = %#v, want %#v", row.Labels, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunLoggerAttachesRunLabels(t *testing.T) {
+	t.Run("Should log the run id and labels on every record", func(t *testing.T) {
+		var buf bytes.Buffer
+		base := slog.New(slog.NewJSONHandler(&buf, nil))
+		runLogger(base, "run-logged", map[string]string{"team": "core", "env": "prod"}).Info("run event")
+
+		var record map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatalf("decode log record: %v", err)
+		}
+		if record["run_id"] != "run-logged" {
+			t.Fatalf("run_id = %v, want run-logged", record["run_id"])
+		}
+		labels, ok := record["labels"].(map[string]any)
+		if !ok || labels["team"] != "core" || labels["env"] != "prod" || len(labels) != 2 {
+			t.Fatalf("labels = %#v, want team=core env=prod", record["labels"])
+		}
+	})
+
+	t.Run("Should omit the labels group for unlabeled runs", func(t *testing.T) {
+		var buf bytes.Buffer
+		base := slog.New(slog.NewJSONHandler(&buf, nil))
+		runLogger(base, "run-plain", nil).Info("run event")
+
+		var record map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatalf("decode log record: %v", err)
+		}
+		if _, ok := record["labels"]; ok {
+			t.Fatalf("record = %#v, want no labels group", record)
+		}
+	})
+}
@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -121,11 +123,20 @@ type RunManager struct {
 	mu                  sync.RWMutex
 	active              map[string]*activeRun
 	activeReviewWatches map[reviewWatchKey]string
+	workflowSlots       map[workflowSlotKey]int
+	workflowSlotFreed   chan struct{}
+	dedupeStarts        map[string]*pendingDedupeStart
 
 	runWG   sync.WaitGroup
 	runDBMu sync.Mutex
 	runDBs  map[string]*cachedRunDB
 
+	backgroundMu      sync.Mutex
+	backgroundCtx     context.Context
+	backgroundCancel  context.CancelFunc
+	backgroundStopped bool
+	backgroundWG      sync.WaitGroup
+
 	metricsMu               sync.RWMutex
 	terminalTotals          map[string]uint64
 	acpStallTotals          map[string]uint64
@@ -133,6 +144,13 @@ type RunManager struct {
 	journalNonTerminalDrops uint64
 	journalDropsByRun       map[string]journalDropTotals
 	incompleteRunIDs        map[string]struct{}
+	customMetrics           map[string]*customMetricFamily
+	customMetricSeriesCount int
+	customMetricDrops       uint64
+	retentionPurgedRuns     uint64
+	retentionArchivedRuns   uint64
+	retentionFailures       uint64
+	reapedTotals            map[string]uint64
 	workspaceEvents         *eventspkg.Bus[apicore.WorkspaceEvent]
 	workspaceEventSeq       atomic.Uint64
 }
@@ -155,6 +173,10 @@ type activeRun struct {
 	taskMulti      *preparedTaskMulti
 	jobControls    *model.JobControlRegistry
 	recovery       workspacecfg.AgentRecoveryConfig
+	releaseSlot    func()
+	dedupeKey      string
+	startedAt      time.Time
+	logger         *slog.Logger
 
 	stateMu         sync.RWMutex
 	cancelRequested bool
@@ -216,6 +238,7 @@ type startRunSpec struct {
 	reviewWatchKey   *reviewWatchKey
 	taskMulti        *preparedTaskMulti
 	recovery         workspacecfg.AgentRecoveryConfig
+	dedupeKey        string
 }
 
 type terminalState struct {
@@ -288,11 +311,15 @@ func NewRunManager(cfg RunManagerConfig) (*RunManager, error) {
 		runDBIdleTTL:           resolveRunManagerRunDBCacheTTL(cfg.RunDBCacheTTL),
 		active:                 make(map[string]*activeRun),
 		activeReviewWatches:    make(map[reviewWatchKey]string),
+		workflowSlots:          make(map[workflowSlotKey]int),
+		workflowSlotFreed:      make(chan struct{}),
 		runDBs:                 make(map[string]*cachedRunDB),
 		terminalTotals:         make(map[string]uint64),
 		acpStallTotals:         make(map[string]uint64),
+		reapedTotals:           make(map[string]uint64),
 		journalDropsByRun:      make(map[string]journalDropTotals),
 		incompleteRunIDs:       make(map[string]struct{}),
+		customMetrics:          make(map[string]*customMetricFamily),
 		workspaceEvents:        eventspkg.New[apicore.WorkspaceEvent](workspaceStreamBufferSize),
 	}, nil
 }
@@ -510,6 +537,11 @@ func (m *RunManager) StartTaskRun(
 		return run, err
 	}
 
+	dedupeKey, err := runDedupeKey(runModeTask, workspaceRow.ID, workflowSlug, req)
+	if err != nil {
+		return apicore.Run{}, err
+	}
+
 	return m.startRun(ctx, startRunSpec{
 		workspace:        workspaceRow,
 		workflowID:       workflowID,
@@ -519,6 +551,7 @@ func (m *RunManager) StartTaskRun(
 		presentationMode: presentationMode,
 		runtimeCfg:       runtimeCfg,
 		recovery:         recoveryCfg,
+		dedupeKey:        dedupeKey,
 	})
 }
 
@@ -577,6 +610,13 @@ func (m *RunManager) startReviewRun(
 	if err != nil {
 		return apicore.Run{}, err
 	}
+	dedupeKey, err := runDedupeKey(runModeReview, workspaceRow.ID, workflowSlug, struct {
+		Round   int                      `json:"round"`
+		Request apicore.ReviewRunRequest `json:"request"`
+	}{Round: round, Request: req})
+	if err != nil {
+		return apicore.Run{}, err
+	}
 
 	return m.startRun(ctx, startRunSpec{
 		workspace:        workspaceRow,
@@ -588,6 +628,7 @@ func (m *RunManager) startReviewRun(
 		parentRunID:      strings.TrimSpace(parentRunID),
 		runtimeCfg:       runtimeCfg,
 		recovery:         recoveryCfg,
+		dedupeKey:        dedupeKey,
 	})
 }
 
@@ -617,6 +658,7 @@ func (m *RunManager) List(ctx context.Context, query apicore.RunListQuery) ([]ap
 		Status:   strings.TrimSpace(query.Status),
 		Statuses: query.Statuses,
 		Mode:     strings.TrimSpace(query.Mode),
+		Labels:   query.Labels,
 		Limit:    query.Limit,
 	}
 	if opts.Limit <= 0 {
@@ -660,6 +702,29 @@ func (m *RunManager) Get(ctx context.Context, runID string) (apicore.Run, error)
 	return m.toCoreRun(detachContext(ctx), row, "")
 }
 
+// Wait blocks until the run reaches a terminal status or ctx ends, then
+// returns the latest run summary. Runs this daemon is not executing return
+// immediately with their stored state.
+func (m *RunManager) Wait(ctx context.Context, runID string) (apicore.Run, error) {
+	trimmedRunID := strings.TrimSpace(runID)
+	run, err := m.Get(ctx, trimmedRunID)
+	if err != nil || isTerminalRunStatus(run.Status) {
+		return run, err
+	}
+	active := m.getActive(trimmedRunID)
+	if active == nil {
+		// The run may have settled between the read above and the lookup, so
+		// re-read instead of returning the stale non-terminal row.
+		return m.Get(ctx, trimmedRunID)
+	}
+	select {
+	case <-active.done:
+	case <-ctx.Done():
+	case <-m.lifecycleCtx.Done():
+	}
+	return m.Get(ctx, trimmedRunID)
+}
+
 // Snapshot returns the dense attach snapshot for one run.
 func (m *RunManager) Snapshot(ctx context.Context, runID string) (apicore.RunSnapshot, error) {
 	listCtx := detachContext(ctx)
@@ -1346,6 +1411,27 @@ func (m *RunManager) startRun(ctx context.Context, spec startRunSpec) (apicore.R
 	if err := ensureHomeLayout(m.homePaths); err != nil {
 		return apicore.Run{}, err
 	}
+	if err := m.checkMaintenance(ctx, spec); err != nil {
+		return apicore.Run{}, err
+	}
+	duplicate, joined, releaseDedupe, err := m.joinDuplicateRun(ctx, spec)
+	if err != nil {
+		return apicore.Run{}, err
+	}
+	if joined {
+		return duplicate, nil
+	}
+	defer releaseDedupe()
+	releaseSlot, err := m.acquireRunSlots(ctx, spec)
+	if err != nil {
+		return apicore.Run{}, err
+	}
+	slotHandedOff := false
+	defer func() {
+		if !slotHandedOff {
+			releaseSlot()
+		}
+	}()
 
 	runtimeCfg := spec.runtimeCfg.Clone()
 	runtimeCfg.RunsDir = m.homePaths.RunsDir
@@ -1371,7 +1457,9 @@ func (m *RunManager) startRun(ctx context.Context, spec startRunSpec) (apicore.R
 	if err != nil {
 		return apicore.Run{}, err
 	}
-	runCtx, cancel := context.WithCancel(withRequestID(m.lifecycleCtx, requestID))
+	runCtx, cancel := context.WithCancel(
+		apicore.WithRunLabels(withRequestID(m.lifecycleCtx, requestID), row.Labels),
+	)
 	started := false
 	defer func() {
 		if !started {
@@ -1394,7 +1482,10 @@ func (m *RunManager) startRun(ctx context.Context, spec startRunSpec) (apicore.R
 		active.cancel()
 		return apicore.Run{}, m.failStartRun(runCtx, row, active.currentCloseTimeout(), scope, createdRun, err)
 	}
+	active.releaseSlot = releaseSlot
+	slotHandedOff = true
 	m.setActive(active)
+	m.startCustomMetricObserver(active, row.Labels)
 
 	m.runWG.Add(1)
 	go m.runAsync(active, row, runtimeCfg)
@@ -1478,6 +1569,7 @@ func (m *RunManager) insertRunRow(
 		PresentationMode: spec.presentationMode,
 		StartedAt:        startedAt,
 		RequestID:        requestID,
+		Labels:           apicore.RunLabelsFromContext(ctx),
 	})
 	if err != nil {
 		cleanupRunDirectory(runArtifacts.RunDir)
@@ -1533,6 +1625,7 @@ func (m *RunManager) resumeExistingExecRun(
 			PresentationMode: spec.presentationMode,
 			StartedAt:        record.CreatedAt.UTC(),
 			RequestID:        requestID,
+			Labels:           apicore.RunLabelsFromContext(ctx),
 		})
 		if err != nil {
 			return globaldb.Run{}, false, false, err
@@ -1559,6 +1652,9 @@ func (m *RunManager) resumeExistingExecRun(
 	row.EndedAt = nil
 	row.ErrorText = ""
 	row.RequestID = requestID
+	if labels := apicore.RunLabelsFromContext(ctx); len(labels) > 0 {
+		row.Labels = labels
+	}
 
 	updatedRow, err := m.globalDB.UpdateRun(ctx, row)
 	if err != nil {
@@ -1633,7 +1729,33 @@ func newActiveRun(
 		reviewWatchKey: cloneReviewWatchKey(spec.reviewWatchKey),
 		taskMulti:      spec.taskMulti,
 		recovery:       spec.recovery.ApplyDefaults(),
+		dedupeKey:      spec.dedupeKey,
+		startedAt:      row.StartedAt,
+		logger:         runLogger(slog.Default(), row.RunID, row.Labels),
+	}
+}
+
+// runLogger returns the run-scoped logger, building one from the default
+// logger for runs that were not created through newActiveRun.
+func (r *activeRun) runLogger() *slog.Logger {
+	if r.logger == nil {
+		return runLogger(slog.Default(), r.runID, nil)
 	}
+	return r.logger
+}
+
+// runLogger scopes base to one run so every record it writes carries the run
+// id and, when the caller attached any, the run labels as a "labels" group.
+func runLogger(base *slog.Logger, runID string, labels map[string]string) *slog.Logger {
+	logger := base.With("run_id", runID)
+	if len(labels) == 0 {
+		return logger
+	}
+	attrs := make([]any, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		attrs = append(attrs, slog.String(key, labels[key]))
+	}
+	return logger.With(slog.Group("labels", attrs...))
 }
 
 func (m *RunManager) failStartRun(
@@ -1683,7 +1805,7 @@ func (m *RunManager) startWatcher(active *activeRun) error {
 			m.publishArtifactWorkspaceEvent(ctx, active, item)
 			return nil
 		},
-		Logger: slog.Default(),
+		Logger: active.runLogger(),
 	})
 	if err != nil {
 		return err
@@ -1744,7 +1866,7 @@ func (m *RunManager) executeWorkflowRun(active *activeRun, row globaldb.Run, run
 
 	if shouldWrapDaemonRecovery(runtimeCfg, active.recovery) {
 		prepared := newDaemonWorkflowPreparedRun(m, runtimeCfg, scope)
-		_, err := m.runWithRecovery(active.ctx, prepared, runtimeCfg, active.recovery, scope)
+		_, err := m.runWithRecovery(active.ctx, active.runLogger(), prepared, runtimeCfg, active.recovery, scope)
 		switch {
 		case errors.Is(err, plan.ErrNoWork):
 			fallback = completedTerminalState(scope.RunArtifacts(), completedNoWorkSummary)
@@ -1816,7 +1938,7 @@ func (m *RunManager) executeExecRun(active *activeRun, row globaldb.Run, runtime
 
 	if shouldWrapDaemonRecovery(runtimeCfg, active.recovery) {
 		prepared := newDaemonExecPreparedRun(m, runtimeCfg, scope)
-		_, err := m.runWithRecovery(active.ctx, prepared, runtimeCfg, active.recovery, scope)
+		_, err := m.runWithRecovery(active.ctx, active.runLogger(), prepared, runtimeCfg, active.recovery, scope)
 		fallback = fallbackTerminalState(scope.RunArtifacts(), err, active.cancelWasRequested())
 		m.finishRun(active, row, fallback)
 		return
@@ -1829,6 +1951,7 @@ func (m *RunManager) executeExecRun(active *activeRun, row globaldb.Run, runtime
 
 func (m *RunManager) runWithRecovery(
 	ctx context.Context,
+	logger *slog.Logger,
 	prepared recovery.PreparedRun,
 	runtimeCfg *model.RuntimeConfig,
 	recoveryCfg workspacecfg.AgentRecoveryConfig,
@@ -1839,7 +1962,7 @@ func (m *RunManager) runWithRecovery(
 		recoveryCfg,
 		recovery.WithFailedRunConfig(runtimeCfg),
 		recovery.WithRecoveryEventSink(daemonRecoveryEventSink(scope)),
-		recovery.WithRecoveryLogger(slog.Default()),
+		recovery.WithRecoveryLogger(logger),
 	)
 	return orchestrator.Run(ctx, prepared)
 }
@@ -1859,7 +1982,7 @@ func daemonRecoveryEventSink(scope model.RunScope) recovery.EventSink {
 func (m *RunManager) finishRun(active *activeRun, row globaldb.Run, fallback terminalState) {
 	scope := active.scope
 	if err := active.stopWatcher(); err != nil {
-		slog.Default().Warn("daemon: stop workflow watcher", "run_id", active.runID, "error", err)
+		active.runLogger().Warn("daemon: stop workflow watcher", "error", err)
 	}
 	terminal, err := m.resolveTerminalState(detachContext(active.ctx), scope.RunArtifacts().RunID, fallback, scope)
 	if err != nil {
@@ -1874,7 +1997,7 @@ func (m *RunManager) finishRun(active *activeRun, row globaldb.Run, fallback ter
 	}
 
 	if err := m.persistRuntimeIntegrity(detachContext(active.ctx), row.RunID, scope); err != nil {
-		slog.Default().Warn("daemon run integrity persistence failed", "run_id", row.RunID, "error", err)
+		active.runLogger().Warn("daemon run integrity persistence failed", "error", err)
 	}
 	if closeErr := closeRunScope(active.ctx, scope, active.currentCloseTimeout()); closeErr != nil {
 		// Best-effort teardown should not block the terminal row mirror.
@@ -1928,6 +2051,7 @@ func (m *RunManager) toCoreRun(
 		EndedAt:          row.EndedAt,
 		ErrorText:        row.ErrorText,
 		RequestID:        row.RequestID,
+		Labels:           maps.Clone(row.Labels),
 	}
 
 	if row.WorkflowID != nil {
@@ -1974,6 +2098,9 @@ func (m *RunManager) removeActive(runID string) {
 		delete(m.activeReviewWatches, *active.reviewWatchKey)
 	}
 	m.mu.Unlock()
+	if active != nil && active.releaseSlot != nil {
+		active.releaseSlot()
+	}
 	if err := m.evictRunDB(trimmed); err != nil {
 		slog.Default().Warn("daemon: evict cached run db", "run_id", trimmed, "error", err)
 	}
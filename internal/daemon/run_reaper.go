@@ -0,0 +1,143 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+)
+
+const (
+	reapReasonMaxRunDuration = "max_run_duration"
+	reapReasonOrphaned       = "orphaned"
+
+	// staleRunGracePeriod keeps the reaper away from rows that were just
+	// inserted and are still being handed to the active-run registry.
+	staleRunGracePeriod = time.Minute
+)
+
+var daemonReapReasons = []string{reapReasonMaxRunDuration, reapReasonOrphaned}
+
+// StartReaper checks for stale runs on every runs.reap_interval tick until the
+// daemon shuts down, and Shutdown waits for an in-flight check to finish. Runs
+// past runs.max_run_duration are canceled, and starting or running rows that no
+// live run owns are marked crashed. A zero interval turns the reaper off.
+func (m *RunManager) StartReaper(settings RunLifecycleSettings) {
+	if m == nil || settings.ReapInterval <= 0 {
+		return
+	}
+	m.startBackgroundLoop(func(ctx context.Context) {
+		m.runReaperLoop(ctx, settings)
+	})
+}
+
+func (m *RunManager) runReaperLoop(ctx context.Context, settings RunLifecycleSettings) {
+	ticker := time.NewTicker(settings.ReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		m.reapStaleRuns(ctx, settings)
+	}
+}
+
+func (m *RunManager) reapStaleRuns(ctx context.Context, settings RunLifecycleSettings) {
+	if ctx.Err() != nil {
+		return
+	}
+	now := m.now().UTC()
+	if settings.MaxRunDuration > 0 {
+		m.reapOverdueRuns(now, settings.MaxRunDuration)
+	}
+	if err := m.reapOrphanedRuns(ctx, now); err != nil && ctx.Err() == nil {
+		slog.Warn("daemon: stale run reap failed", "error", err)
+	}
+}
+
+// reapOverdueRuns cancels live runs that have been active longer than
+// maxDuration. The runs settle through the normal cancellation path.
+func (m *RunManager) reapOverdueRuns(now time.Time, maxDuration time.Duration) {
+	for _, active := range m.activeSnapshot() {
+		if active == nil || active.startedAt.IsZero() {
+			continue
+		}
+		elapsed := now.Sub(active.startedAt)
+		if elapsed <= maxDuration {
+			continue
+		}
+		if !active.markCancelRequested() {
+			continue
+		}
+		slog.Warn(
+			"daemon: canceling run past runs.max_run_duration",
+			"run_id", active.runID,
+			"elapsed", elapsed.Round(time.Second).String(),
+			"max_run_duration", maxDuration.String(),
+		)
+		active.cancel()
+		m.recordReapedRun(reapReasonMaxRunDuration)
+	}
+}
+
+// reapOrphanedRuns marks starting or running rows crashed when no live run in
+// this daemon owns them, for example after an executor exits without
+// settling its row.
+func (m *RunManager) reapOrphanedRuns(ctx context.Context, now time.Time) error {
+	rows, err := m.globalDB.ListInterruptedRuns(ctx)
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-staleRunGracePeriod)
+	for i := range rows {
+		row := rows[i]
+		if !row.StartedAt.Before(cutoff) || m.getActive(row.RunID) != nil {
+			continue
+		}
+		errorText := fmt.Sprintf("daemon reaped run %s: no live executor owns it", row.RunID)
+		marked, err := m.globalDB.MarkStaleRunCrashed(ctx, row.RunID, now, errorText)
+		if err != nil {
+			return fmt.Errorf("daemon: reap run %s: %w", row.RunID, err)
+		}
+		if !marked {
+			continue
+		}
+		slog.Warn("daemon: reaped orphaned run", "run_id", row.RunID, "status", row.Status)
+		m.recordReapedRun(reapReasonOrphaned)
+		row.Status = runStatusCrashed
+		row.EndedAt = &now
+		row.ErrorText = errorText
+		m.publishRunWorkspaceEvent(ctx, row, "", apicore.WorkspaceEventKindRunTerminal)
+	}
+	return nil
+}
+
+func (m *RunManager) recordReapedRun(reason string) {
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+	if m.reapedTotals == nil {
+		m.reapedTotals = make(map[string]uint64)
+	}
+	m.reapedTotals[reason]++
+}
+
+// ReapedRunTotals reports daemon-lifetime runs handled by the stale run
+// reaper, keyed by reason.
+func (m *RunManager) ReapedRunTotals() map[string]uint64 {
+	if m == nil {
+		return nil
+	}
+	m.metricsMu.RLock()
+	defer m.metricsMu.RUnlock()
+
+	out := make(map[string]uint64, len(m.reapedTotals))
+	for reason, total := range m.reapedTotals {
+		out[reason] = total
+	}
+	return out
+}
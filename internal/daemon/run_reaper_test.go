@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+func TestRunManagerReapStaleRuns(t *testing.T) {
+	env := newRunManagerTestEnv(t, runManagerTestDeps{})
+
+	workspace, err := env.globalDB.ResolveOrRegister(context.Background(), env.workspaceRoot)
+	if err != nil {
+		t.Fatalf("ResolveOrRegister(%q) error = %v", env.workspaceRoot, err)
+	}
+	now := time.Now().UTC()
+	for _, run := range []globaldb.Run{
+		{RunID: "reap-orphan", Status: runStatusRunning, StartedAt: now.Add(-time.Hour)},
+		{RunID: "reap-fresh", Status: runStatusStarting, StartedAt: now},
+		{RunID: "reap-overdue", Status: runStatusRunning, StartedAt: now.Add(-3 * time.Hour)},
+		{RunID: "reap-recent", Status: runStatusRunning, StartedAt: now.Add(-time.Hour)},
+	} {
+		run.WorkspaceID = workspace.ID
+		run.Mode = "task"
+		run.PresentationMode = "stream"
+		if _, err := env.globalDB.PutRun(context.Background(), run); err != nil {
+			t.Fatalf("PutRun(%q) error = %v", run.RunID, err)
+		}
+	}
+
+	canceled := make(map[string]bool)
+	for _, run := range []struct {
+		runID     string
+		startedAt time.Time
+	}{
+		{runID: "reap-overdue", startedAt: now.Add(-3 * time.Hour)},
+		{runID: "reap-recent", startedAt: now.Add(-time.Hour)},
+	} {
+		runID := run.runID
+		env.manager.setActive(&activeRun{
+			runID:     runID,
+			startedAt: run.startedAt,
+			done:      make(chan struct{}),
+			cancel:    func() { canceled[runID] = true },
+		})
+		t.Cleanup(func() { env.manager.removeActive(runID) })
+	}
+
+	env.manager.reapStaleRuns(context.Background(), RunLifecycleSettings{
+		MaxRunDuration: 2 * time.Hour,
+		ReapInterval:   time.Minute,
+	})
+
+	t.Run("Should cancel live runs past the max run duration", func(t *testing.T) {
+		if !canceled["reap-overdue"] || canceled["reap-recent"] {
+			t.Fatalf("canceled runs = %#v, want only reap-overdue", canceled)
+		}
+	})
+
+	t.Run("Should mark orphaned rows crashed after the grace period", func(t *testing.T) {
+		orphan, err := env.globalDB.GetRun(context.Background(), "reap-orphan")
+		if err != nil {
+			t.Fatalf("GetRun(reap-orphan) error = %v", err)
+		}
+		if orphan.Status != runStatusCrashed || !strings.Contains(orphan.ErrorText, "no live executor") {
+			t.Fatalf("unexpected orphan row: %#v", orphan)
+		}
+		for _, runID := range []string{"reap-fresh", "reap-overdue", "reap-recent"} {
+			row, err := env.globalDB.GetRun(context.Background(), runID)
+			if err != nil {
+				t.Fatalf("GetRun(%q) error = %v", runID, err)
+			}
+			if row.Status == runStatusCrashed {
+				t.Fatalf("run %q was reaped, want it left alone", runID)
+			}
+		}
+	})
+
+	t.Run("Should count reaped runs by reason", func(t *testing.T) {
+		totals := env.manager.ReapedRunTotals()
+		if totals[reapReasonMaxRunDuration] != 1 || totals[reapReasonOrphaned] != 1 {
+			t.Fatalf("ReapedRunTotals() = %#v, want one of each reason", totals)
+		}
+	})
+}
+
+func TestRunManagerShutdownStopsReaper(t *testing.T) {
+	env := newRunManagerTestEnv(t, runManagerTestDeps{})
+	env.manager.StartReaper(RunLifecycleSettings{ReapInterval: time.Millisecond})
+
+	if err := env.manager.Shutdown(context.Background(), false); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		env.manager.backgroundWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reaper loop still running after Shutdown()")
+	}
+}
@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// StartRetention purges terminal runs once at startup and then on every
+// runs.purge_interval tick until the daemon lifecycle ends. A zero interval
+// leaves retention to `compozy runs purge`.
+func (m *RunManager) StartRetention(settings RunLifecycleSettings) {
+	if m == nil || settings.PurgeInterval <= 0 {
+		return
+	}
+	m.startBackgroundLoop(func(ctx context.Context) {
+		m.runRetentionLoop(ctx, settings)
+	})
+}
+
+// startBackgroundLoop runs loop on a context that Shutdown cancels, and tracks
+// it so Shutdown can wait for it to return before the stores close.
+func (m *RunManager) startBackgroundLoop(loop func(context.Context)) {
+	m.backgroundMu.Lock()
+	defer m.backgroundMu.Unlock()
+	if m.backgroundStopped {
+		return
+	}
+	if m.backgroundCancel == nil {
+		m.backgroundCtx, m.backgroundCancel = context.WithCancel(resolveRunManagerLifecycleContext(m.lifecycleCtx))
+	}
+	ctx := m.backgroundCtx
+	m.backgroundWG.Add(1)
+	go func() {
+		defer m.backgroundWG.Done()
+		loop(ctx)
+	}()
+}
+
+// stopBackgroundLoops cancels the background loops, keeps new ones from
+// starting, and waits for running ones until ctx ends.
+func (m *RunManager) stopBackgroundLoops(ctx context.Context) error {
+	m.backgroundMu.Lock()
+	m.backgroundStopped = true
+	if m.backgroundCancel != nil {
+		m.backgroundCancel()
+	}
+	m.backgroundMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.backgroundWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for background loops: %w", context.Cause(ctx))
+	}
+}
+
+func (m *RunManager) runRetentionLoop(ctx context.Context, settings RunLifecycleSettings) {
+	ticker := time.NewTicker(settings.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		m.purgeForRetention(ctx, settings)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *RunManager) purgeForRetention(ctx context.Context, settings RunLifecycleSettings) {
+	if ctx.Err() != nil {
+		return
+	}
+	result, err := m.Purge(ctx, settings)
+	if err != nil && ctx.Err() != nil {
+		// Shutdown stopped the pass between runs; that is not a failed purge.
+		m.recordRetentionPass(result, nil)
+		return
+	}
+	m.recordRetentionPass(result, err)
+	if err != nil {
+		slog.Warn("daemon: scheduled run purge failed", "purged", len(result.PurgedRunIDs), "error", err)
+		return
+	}
+	if len(result.PurgedRunIDs) > 0 {
+		slog.Info(
+			"daemon: scheduled run purge completed",
+			"purged",
+			len(result.PurgedRunIDs),
+			"archived",
+			len(result.ArchivedRunIDs),
+			"worktrees_removed",
+			len(result.PurgedWorktreePaths),
+		)
+	}
+}
+
+func (m *RunManager) recordRetentionPass(result RunPurgeResult, err error) {
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+	m.retentionPurgedRuns += uint64(len(result.PurgedRunIDs))
+	m.retentionArchivedRuns += uint64(len(result.ArchivedRunIDs))
+	if err != nil {
+		m.retentionFailures++
+	}
+}
+
+// RetentionTotals reports daemon-lifetime runs removed by scheduled purges and
+// scheduled purge passes that failed.
+func (m *RunManager) RetentionTotals() (uint64, uint64) {
+	if m == nil {
+		return 0, 0
+	}
+	m.metricsMu.RLock()
+	defer m.metricsMu.RUnlock()
+	return m.retentionPurgedRuns, m.retentionFailures
+}
+
+// RetentionArchivedTotal reports daemon-lifetime runs archived to
+// runs.archive_dir by scheduled purges.
+func (m *RunManager) RetentionArchivedTotal() uint64 {
+	if m == nil {
+		return 0
+	}
+	m.metricsMu.RLock()
+	defer m.metricsMu.RUnlock()
+	return m.retentionArchivedRuns
+}
@@ -0,0 +1,142 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+func TestRunManagerRetentionLoopPurgesOnStartAndStopsWithLifecycle(t *testing.T) {
+	env := newRunManagerTestEnv(t, runManagerTestDeps{})
+
+	workspace, err := env.globalDB.ResolveOrRegister(context.Background(), env.workspaceRoot)
+	if err != nil {
+		t.Fatalf("ResolveOrRegister(%q) error = %v", env.workspaceRoot, err)
+	}
+	seedTerminalRunForPurge(
+		t,
+		env.manager,
+		env.globalDB,
+		workspace.ID,
+		"retention-old",
+		runStatusCompleted,
+		time.Now().UTC().AddDate(0, 0, -30),
+	)
+	seedTerminalRunForPurge(
+		t,
+		env.manager,
+		env.globalDB,
+		workspace.ID,
+		"retention-recent",
+		runStatusCompleted,
+		time.Now().UTC().Add(-time.Hour),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		env.manager.runRetentionLoop(ctx, RunLifecycleSettings{
+			KeepTerminalDays: 14,
+			KeepMax:          100,
+			PurgeInterval:    time.Hour,
+		})
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		purged, failures := env.manager.RetentionTotals()
+		if failures != 0 {
+			t.Fatalf("RetentionTotals() failures = %d, want 0", failures)
+		}
+		if purged == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("RetentionTotals() purged = %d, want 1 before deadline", purged)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, err := env.globalDB.GetRun(context.Background(), "retention-old"); !errors.Is(err, globaldb.ErrRunNotFound) {
+		t.Fatalf("GetRun(retention-old) error = %v, want ErrRunNotFound", err)
+	}
+	if _, err := env.globalDB.GetRun(context.Background(), "retention-recent"); err != nil {
+		t.Fatalf("GetRun(retention-recent) error = %v, want row kept", err)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("retention loop did not stop after lifecycle cancellation")
+	}
+}
+
+func TestRunManagerShutdownWaitsForBackgroundLoops(t *testing.T) {
+	env := newRunManagerTestEnv(t, runManagerTestDeps{})
+
+	var finished atomic.Bool
+	env.manager.startBackgroundLoop(func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+	})
+
+	if err := env.manager.Shutdown(context.Background(), false); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	t.Run("Should return only after running loops finish", func(t *testing.T) {
+		if !finished.Load() {
+			t.Fatal("Shutdown() returned before the background loop finished")
+		}
+	})
+
+	t.Run("Should not start loops after shutdown", func(t *testing.T) {
+		started := make(chan struct{}, 1)
+		env.manager.startBackgroundLoop(func(context.Context) {
+			started <- struct{}{}
+		})
+		select {
+		case <-started:
+			t.Fatal("background loop started after Shutdown()")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+func TestRunManagerPurgeStopsBetweenRunsWhenContextEnds(t *testing.T) {
+	env := newRunManagerTestEnv(t, runManagerTestDeps{})
+
+	workspace, err := env.globalDB.ResolveOrRegister(context.Background(), env.workspaceRoot)
+	if err != nil {
+		t.Fatalf("ResolveOrRegister(%q) error = %v", env.workspaceRoot, err)
+	}
+	seedTerminalRunForPurge(
+		t,
+		env.manager,
+		env.globalDB,
+		workspace.ID,
+		"retention-canceled",
+		runStatusCompleted,
+		time.Now().UTC().AddDate(0, 0, -30),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := env.manager.Purge(ctx, RunLifecycleSettings{KeepTerminalDays: 14, KeepMax: 100})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Purge(canceled) error = %v, want context.Canceled", err)
+	}
+	if len(result.PurgedRunIDs) != 0 {
+		t.Fatalf("Purge(canceled) purged = %v, want none", result.PurgedRunIDs)
+	}
+	if _, err := env.globalDB.GetRun(context.Background(), "retention-canceled"); err != nil {
+		t.Fatalf("GetRun(retention-canceled) error = %v, want row kept", err)
+	}
+}
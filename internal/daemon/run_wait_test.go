@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/compozy/compozy/internal/core/model"
+)
+
+func TestRunManagerWaitReturnsOnTerminalStatusOrTimeout(t *testing.T) {
+	release := make(chan struct{})
+	env := newRunManagerTestEnv(t, runManagerTestDeps{
+		prepare: func(context.Context, *model.RuntimeConfig, model.RunScope) (*model.SolvePreparation, error) {
+			return &model.SolvePreparation{}, nil
+		},
+		execute: func(ctx context.Context, _ *model.SolvePreparation, _ *model.RuntimeConfig) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	run, err := env.manager.StartTaskRun(
+		context.Background(),
+		env.workspaceRoot,
+		env.workflowSlug,
+		apicore.TaskRunRequest{Workspace: env.workspaceRoot, PresentationMode: defaultPresentationMode},
+	)
+	if err != nil {
+		t.Fatalf("StartTaskRun() error = %v", err)
+	}
+
+	t.Run("Should return the current state when the wait elapses", func(t *testing.T) {
+		waitCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		got, err := env.manager.Wait(waitCtx, run.RunID)
+		if err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+		if isTerminalRunStatus(got.Status) {
+			t.Fatalf("Wait() status = %q, want a non-terminal status", got.Status)
+		}
+	})
+
+	t.Run("Should return the terminal run once it finishes", func(t *testing.T) {
+		waitCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		close(release)
+		got, err := env.manager.Wait(waitCtx, run.RunID)
+		if err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+		if got.Status != runStatusCompleted {
+			t.Fatalf("Wait() status = %q, want %q", got.Status, runStatusCompleted)
+		}
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/compozy/compozy/internal/api/contract"
 	apicore "github.com/compozy/compozy/internal/api/core"
 	compozyconfig "github.com/compozy/compozy/internal/config"
+	"github.com/compozy/compozy/internal/store"
 	"github.com/compozy/compozy/internal/store/globaldb"
 )
 
@@ -182,7 +183,11 @@ func (s *Service) Metrics(ctx context.Context) (apicore.MetricsPayload, error) {
 	s.writeJournalDropMetrics(&builder)
 	s.writeRunTerminalMetrics(&builder)
 	s.writeACPStallMetrics(&builder)
+	s.writeRetentionMetrics(&builder)
+	s.writeReaperMetrics(&builder)
+	s.writeGlobalDBWriteQueueMetrics(&builder)
 	s.writeUptimeMetric(&builder)
+	s.writeCustomMetrics(&builder)
 	return apicore.MetricsPayload{
 		Body:        builder.String(),
 		ContentType: "text/plain; version=0.0.4; charset=utf-8",
@@ -278,6 +283,74 @@ func (s *Service) writeACPStallMetrics(builder *strings.Builder) {
 	}
 }
 
+func (s *Service) writeRetentionMetrics(builder *strings.Builder) {
+	purged, failures := s.retentionTotals()
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_retention_purged_runs_total",
+		"counter",
+		"Terminal runs removed by scheduled retention purges",
+	)
+	fmt.Fprintf(builder, "daemon_retention_purged_runs_total %d\n", purged)
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_retention_archived_runs_total",
+		"counter",
+		"Terminal runs archived to runs.archive_dir before scheduled retention purged them",
+	)
+	fmt.Fprintf(builder, "daemon_retention_archived_runs_total %d\n", s.retentionArchivedTotal())
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_retention_failures_total",
+		"counter",
+		"Scheduled retention purges that failed",
+	)
+	fmt.Fprintf(builder, "daemon_retention_failures_total %d\n", failures)
+}
+
+func (s *Service) writeReaperMetrics(builder *strings.Builder) {
+	reaped := s.reapedRunTotals()
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_runs_reaped_total",
+		"counter",
+		"Stale runs canceled or marked crashed by the run reaper",
+	)
+	for _, reason := range daemonReapReasons {
+		fmt.Fprintf(builder, "daemon_runs_reaped_total{reason=%q} %d\n", reason, reaped[reason])
+	}
+}
+
+func (s *Service) writeGlobalDBWriteQueueMetrics(builder *strings.Builder) {
+	var stats store.WriteQueueStats
+	if s != nil && s.globalDB != nil {
+		stats = s.globalDB.WriteQueueStats()
+	}
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_globaldb_write_queue_depth",
+		"gauge",
+		"Writers waiting for or holding the global catalog write slot",
+	)
+	fmt.Fprintf(builder, "daemon_globaldb_write_queue_depth %d\n", stats.Depth)
+
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_globaldb_write_busy_retries_total",
+		"counter",
+		"Global catalog writes retried after SQLITE_BUSY or SQLITE_LOCKED",
+	)
+	fmt.Fprintf(builder, "daemon_globaldb_write_busy_retries_total %d\n", stats.BusyRetries)
+
+	writePrometheusMetricPrelude(
+		builder,
+		"daemon_globaldb_write_queue_timeouts_total",
+		"counter",
+		"Global catalog writes rejected after waiting too long for the write slot",
+	)
+	fmt.Fprintf(builder, "daemon_globaldb_write_queue_timeouts_total %d\n", stats.WaitTimeouts)
+}
+
 func (s *Service) writeUptimeMetric(builder *strings.Builder) {
 	writePrometheusMetricPrelude(
 		builder,
@@ -346,6 +419,27 @@ func (s *Service) acpStallTotalsByMode() map[string]uint64 {
 	return s.runManager.ACPStallTotalsByMode()
 }
 
+func (s *Service) retentionTotals() (uint64, uint64) {
+	if s == nil || s.runManager == nil {
+		return 0, 0
+	}
+	return s.runManager.RetentionTotals()
+}
+
+func (s *Service) retentionArchivedTotal() uint64 {
+	if s == nil || s.runManager == nil {
+		return 0
+	}
+	return s.runManager.RetentionArchivedTotal()
+}
+
+func (s *Service) reapedRunTotals() map[string]uint64 {
+	if s == nil || s.runManager == nil {
+		return nil
+	}
+	return s.runManager.ReapedRunTotals()
+}
+
 func (s *Service) journalSubmitDropTotals() (uint64, uint64) {
 	if s == nil || s.runManager == nil {
 		return 0, 0
@@ -360,6 +454,13 @@ func (s *Service) incompleteRunCount() int {
 	return s.runManager.IncompleteRunCount()
 }
 
+func (s *Service) writeCustomMetrics(builder *strings.Builder) {
+	if s == nil || s.runManager == nil {
+		return
+	}
+	s.runManager.writeCustomMetrics(builder)
+}
+
 func (s *Service) countWorkspaces(ctx context.Context) (int, error) {
 	if s == nil || s.globalDB == nil {
 		return 0, nil
@@ -468,11 +569,14 @@ func uptimeSeconds(startedAt time.Time, now time.Time) int64 {
 	return int64(now.Sub(startedAt).Seconds())
 }
 
+// prometheusHelpEscaper keeps extension-supplied help text on one line.
+var prometheusHelpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
 func writePrometheusMetricPrelude(builder *strings.Builder, name string, metricType string, help string) {
 	builder.WriteString("# HELP ")
 	builder.WriteString(name)
 	builder.WriteByte(' ')
-	builder.WriteString(help)
+	builder.WriteString(prometheusHelpEscaper.Replace(help))
 	builder.WriteByte('\n')
 	builder.WriteString("# TYPE ")
 	builder.WriteString(name)
@@ -150,6 +150,8 @@ func TestServiceStatusHealthAndMetricsReflectRuntimeState(t *testing.T) {
 		`daemon_run_terminal_total{mode="task",status="completed"} 2`,
 		`daemon_run_terminal_total{mode="exec",status="failed"} 1`,
 		`daemon_acp_stall_total{mode="review"} 4`,
+		"daemon_globaldb_write_queue_depth 0",
+		"daemon_globaldb_write_busy_retries_total 0",
 		"daemon_uptime_seconds 300",
 	} {
 		if !strings.Contains(metrics.Body, fragment) {
@@ -213,6 +215,11 @@ func TestServiceDefaultsReportStoppedAndZeroCounts(t *testing.T) {
 		`daemon_journal_submit_drops_total{kind="terminal"} 0`,
 		`daemon_run_terminal_total{mode="task",status="completed"} 0`,
 		`daemon_acp_stall_total{mode="task"} 0`,
+		"daemon_retention_purged_runs_total 0",
+		"daemon_retention_archived_runs_total 0",
+		"daemon_retention_failures_total 0",
+		`daemon_runs_reaped_total{reason="orphaned"} 0`,
+		"daemon_globaldb_write_queue_timeouts_total 0",
 		"daemon_uptime_seconds 0",
 	} {
 		if !strings.Contains(metrics.Body, fragment) {
@@ -19,6 +19,7 @@ import (
 // RunPurgeResult captures the terminal runs removed by one purge operation.
 type RunPurgeResult struct {
 	PurgedRunIDs        []string
+	ArchivedRunIDs      []string
 	PurgedWorktreePaths []string
 }
 
@@ -230,6 +231,7 @@ func (m *RunManager) Shutdown(ctx context.Context, force bool) error {
 	activeRuns := m.activeSnapshot()
 	if len(activeRuns) == 0 {
 		return errors.Join(
+			wrapShutdownError("stop background loops", m.stopBackgroundLoops(ctx)),
 			wrapShutdownError("close run db cache", m.closeRunDBCache(ctx)),
 			wrapShutdownError("close workspace event bus", m.closeWorkspaceEventBus(ctx)),
 		)
@@ -268,6 +270,7 @@ func (m *RunManager) Shutdown(ctx context.Context, force bool) error {
 	case <-waitCtx.Done():
 	}
 	return errors.Join(
+		wrapShutdownError("stop background loops", m.stopBackgroundLoops(ctx)),
 		wrapShutdownError("close run db cache", m.closeRunDBCache(ctx)),
 		wrapShutdownError("close workspace event bus", m.closeWorkspaceEventBus(ctx)),
 	)
@@ -288,7 +291,8 @@ func (m *RunManager) closeWorkspaceEventBus(ctx context.Context) error {
 }
 
 // Purge deletes terminal run directories and their durable index rows according
-// to the configured oldest-first retention policy.
+// to the configured oldest-first retention policy. When runs.archive_dir is set,
+// each run is archived first and stays in place if archiving fails.
 func (m *RunManager) Purge(ctx context.Context, settings RunLifecycleSettings) (RunPurgeResult, error) {
 	if m == nil || m.globalDB == nil {
 		return RunPurgeResult{}, errors.New("daemon: run manager global db is required")
@@ -306,10 +310,23 @@ func (m *RunManager) Purge(ctx context.Context, settings RunLifecycleSettings) (
 
 	result := RunPurgeResult{PurgedRunIDs: make([]string, 0, len(candidates))}
 	for i := range candidates {
+		// Each run is removed under a detached context so it is never left
+		// half purged, but a canceled caller stops the pass before the next one.
+		if ctx != nil && ctx.Err() != nil {
+			return result, context.Cause(ctx)
+		}
 		run := &candidates[i]
 		if m.getActive(run.RunID) != nil {
 			continue
 		}
+		archived := false
+		if settings.ArchiveDir != "" {
+			if err := m.archiveRun(listCtx, run, settings.ArchiveDir); err != nil {
+				return result, fmt.Errorf("archive run %s: %w", run.RunID, err)
+			}
+			archived = true
+		}
+
 		purgedWorktrees, err := m.purgeRunWorktrees(listCtx, run, settings)
 		if err != nil {
 			if errors.Is(err, errRunPurgeDeferred) {
@@ -327,6 +344,9 @@ func (m *RunManager) Purge(ctx context.Context, settings RunLifecycleSettings) (
 			return result, fmt.Errorf("delete metadata for run %s: %w", run.RunID, err)
 		}
 		result.PurgedRunIDs = append(result.PurgedRunIDs, run.RunID)
+		if archived {
+			result.ArchivedRunIDs = append(result.ArchivedRunIDs, run.RunID)
+		}
 		result.PurgedWorktreePaths = append(result.PurgedWorktreePaths, purgedWorktrees...)
 	}
 	return result, nil
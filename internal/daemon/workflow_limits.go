@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	workspacecfg "github.com/compozy/compozy/internal/core/workspace"
+)
+
+// workflowSlotKey identifies one workflow for per-workflow run limits. An
+// empty WorkflowSlug counts every top-level run in the workspace instead.
+type workflowSlotKey struct {
+	WorkspaceID  string
+	WorkflowSlug string
+}
+
+// workspaceLimitRetryAfter is the Retry-After hint sent with a start rejected
+// at runs.max_concurrent_per_workspace.
+const workspaceLimitRetryAfter = 30 * time.Second
+
+// acquireRunSlots reserves the workflow slot and then the workspace slot for a
+// run start. The workflow slot comes first so a start queued behind a saturated
+// workflow never holds a workspace slot that other workflows could use. The
+// returned func releases both.
+func (m *RunManager) acquireRunSlots(ctx context.Context, spec startRunSpec) (func(), error) {
+	releaseWorkflow, err := m.acquireWorkflowSlot(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	releaseWorkspace, err := m.acquireWorkspaceSlot(ctx, spec)
+	if err != nil {
+		releaseWorkflow()
+		return nil, err
+	}
+	return func() {
+		releaseWorkflow()
+		releaseWorkspace()
+	}, nil
+}
+
+// acquireWorkspaceSlot reserves one runs.max_concurrent_per_workspace slot for
+// a top-level run of any mode. Child runs share their parent's slot. A full
+// workspace sheds the start with 429 and a Retry-After hint unless the queue
+// policy is set.
+func (m *RunManager) acquireWorkspaceSlot(ctx context.Context, spec startRunSpec) (func(), error) {
+	if strings.TrimSpace(spec.parentRunID) != "" {
+		return func() {}, nil
+	}
+	projectCfg, err := m.loadProjectConfig(ctx, spec.workspace.RootDir)
+	if err != nil {
+		return nil, err
+	}
+	limit := projectCfg.Runs.EffectiveMaxConcurrentPerWorkspace()
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	workspaceID := strings.TrimSpace(spec.workspace.ID)
+	queue := projectCfg.Runs.EffectiveWorkspaceLimitPolicy() == workspacecfg.WorkflowLimitPolicyQueue
+	return m.acquireSlot(ctx, workflowSlotKey{WorkspaceID: workspaceID}, limit, queue, func(active int) error {
+		return workspaceConcurrencyLimitProblem(workspaceID, limit, active)
+	})
+}
+
+// acquireWorkflowSlot reserves one runs.max_concurrent_per_workflow slot for a
+// top-level workflow run. Child runs share their parent's slot, and exec runs
+// have no workflow. With the queue policy the call waits for a slot until ctx
+// or the daemon lifecycle ends; otherwise a full workflow is a conflict.
+func (m *RunManager) acquireWorkflowSlot(ctx context.Context, spec startRunSpec) (func(), error) {
+	slug := strings.TrimSpace(spec.workflowSlug)
+	if spec.mode == runModeExec || slug == "" || strings.TrimSpace(spec.parentRunID) != "" {
+		return func() {}, nil
+	}
+	projectCfg, err := m.loadProjectConfig(ctx, spec.workspace.RootDir)
+	if err != nil {
+		return nil, err
+	}
+	limit := projectCfg.Runs.EffectiveMaxConcurrentPerWorkflow()
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	key := workflowSlotKey{WorkspaceID: strings.TrimSpace(spec.workspace.ID), WorkflowSlug: slug}
+	queue := projectCfg.Runs.EffectiveWorkflowLimitPolicy() == workspacecfg.WorkflowLimitPolicyQueue
+	return m.acquireSlot(ctx, key, limit, queue, func(active int) error {
+		return workflowConcurrencyLimitProblem(slug, limit, active)
+	})
+}
+
+func (m *RunManager) acquireSlot(
+	ctx context.Context,
+	key workflowSlotKey,
+	limit int,
+	queue bool,
+	limitProblem func(active int) error,
+) (func(), error) {
+	for {
+		m.mu.Lock()
+		inUse := m.workflowSlots[key]
+		if inUse < limit {
+			m.workflowSlots[key] = inUse + 1
+			m.mu.Unlock()
+			var once sync.Once
+			return func() {
+				once.Do(func() {
+					m.releaseWorkflowSlot(key)
+				})
+			}, nil
+		}
+		freed := m.workflowSlotFreed
+		m.mu.Unlock()
+
+		if !queue {
+			return nil, limitProblem(inUse)
+		}
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-m.lifecycleCtx.Done():
+			return nil, context.Cause(m.lifecycleCtx)
+		}
+	}
+}
+
+func (m *RunManager) releaseWorkflowSlot(key workflowSlotKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.workflowSlots[key] <= 1 {
+		delete(m.workflowSlots, key)
+	} else {
+		m.workflowSlots[key]--
+	}
+	close(m.workflowSlotFreed)
+	m.workflowSlotFreed = make(chan struct{})
+}
+
+func workflowConcurrencyLimitProblem(workflowSlug string, limit int, active int) error {
+	return apicore.NewProblem(
+		http.StatusConflict,
+		"workflow_concurrency_limit",
+		"workflow already has the maximum number of active runs",
+		map[string]any{
+			"workflow":    workflowSlug,
+			"limit":       limit,
+			"active_runs": active,
+		},
+		nil,
+	)
+}
+
+func workspaceConcurrencyLimitProblem(workspaceID string, limit int, active int) error {
+	problem := apicore.NewProblem(
+		http.StatusTooManyRequests,
+		"workspace_concurrency_limit",
+		"workspace already has the maximum number of active runs",
+		map[string]any{
+			"workspace_id":        workspaceID,
+			"limit":               limit,
+			"active_runs":         active,
+			"retry_after_seconds": int(workspaceLimitRetryAfter.Seconds()),
+		},
+		nil,
+	)
+	problem.RetryAfter = workspaceLimitRetryAfter
+	return problem
+}
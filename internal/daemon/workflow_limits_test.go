@@ -0,0 +1,277 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	apicore "github.com/compozy/compozy/internal/api/core"
+	"github.com/compozy/compozy/internal/core/model"
+	workspacecfg "github.com/compozy/compozy/internal/core/workspace"
+	"github.com/compozy/compozy/internal/store/globaldb"
+)
+
+func TestRunManagerWorkflowConcurrencyLimit(t *testing.T) {
+	t.Run("Should reject starts beyond the per-workflow limit", func(t *testing.T) {
+		release := make(chan struct{})
+		env := newWorkflowLimitTestEnv(t, workspacecfg.WorkflowLimitPolicyReject, release)
+
+		first := env.startTaskRun(t, "limit-reject-first", nil)
+		_, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, env.workflowSlug, apicore.TaskRunRequest{
+			Workspace:        env.workspaceRoot,
+			PresentationMode: defaultPresentationMode,
+			RuntimeOverrides: rawJSON(t, `{"run_id":"limit-reject-second"}`),
+		})
+		var problem *apicore.Problem
+		if !errors.As(err, &problem) {
+			t.Fatalf("StartTaskRun(second) error = %v, want problem", err)
+		}
+		if problem.Status != http.StatusConflict || problem.Code != "workflow_concurrency_limit" {
+			t.Fatalf("problem = status:%d code:%q, want 409 workflow_concurrency_limit", problem.Status, problem.Code)
+		}
+		if _, err := env.globalDB.GetRun(context.Background(), "limit-reject-second"); !errors.Is(err, globaldb.ErrRunNotFound) {
+			t.Fatalf("GetRun(second) error = %v, want ErrRunNotFound", err)
+		}
+
+		close(release)
+		waitForRun(t, env.globalDB, first.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+		third := env.startTaskRun(t, "limit-reject-third", nil)
+		waitForRun(t, env.globalDB, third.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+	})
+
+	t.Run("Should queue starts until a slot frees", func(t *testing.T) {
+		release := make(chan struct{})
+		env := newWorkflowLimitTestEnv(t, workspacecfg.WorkflowLimitPolicyQueue, release)
+
+		first := env.startTaskRun(t, "limit-queue-first", nil)
+		queued := make(chan error, 1)
+		go func() {
+			_, err := env.manager.StartTaskRun(
+				context.Background(),
+				env.workspaceRoot,
+				env.workflowSlug,
+				apicore.TaskRunRequest{
+					Workspace:        env.workspaceRoot,
+					PresentationMode: defaultPresentationMode,
+					RuntimeOverrides: rawJSON(t, `{"run_id":"limit-queue-second"}`),
+				},
+			)
+			queued <- err
+		}()
+
+		select {
+		case err := <-queued:
+			t.Fatalf("queued StartTaskRun() returned early with %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		close(release)
+		waitForRun(t, env.globalDB, first.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+		select {
+		case err := <-queued:
+			if err != nil {
+				t.Fatalf("queued StartTaskRun() error = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("queued StartTaskRun() did not start after the slot freed")
+		}
+		waitForRun(t, env.globalDB, "limit-queue-second", func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+	})
+
+	t.Run("Should stop waiting when the queued caller cancels", func(t *testing.T) {
+		release := make(chan struct{})
+		env := newWorkflowLimitTestEnv(t, workspacecfg.WorkflowLimitPolicyQueue, release)
+
+		first := env.startTaskRun(t, "limit-cancel-first", nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := env.manager.StartTaskRun(ctx, env.workspaceRoot, env.workflowSlug, apicore.TaskRunRequest{
+			Workspace:        env.workspaceRoot,
+			PresentationMode: defaultPresentationMode,
+			RuntimeOverrides: rawJSON(t, `{"run_id":"limit-cancel-second"}`),
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("StartTaskRun(queued) error = %v, want context.DeadlineExceeded", err)
+		}
+		if _, err := env.globalDB.GetRun(context.Background(), "limit-cancel-second"); !errors.Is(err, globaldb.ErrRunNotFound) {
+			t.Fatalf("GetRun(second) error = %v, want ErrRunNotFound", err)
+		}
+
+		close(release)
+		waitForRun(t, env.globalDB, first.RunID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+	})
+}
+
+func TestRunManagerWorkspaceConcurrencyLimit(t *testing.T) {
+	release := make(chan struct{})
+	limit := 1
+	env := newRunManagerTestEnv(t, runManagerTestDeps{
+		loadProjectConfig: func(context.Context, string) (workspacecfg.ProjectConfig, error) {
+			return workspacecfg.ProjectConfig{
+				Runs: workspacecfg.RunsConfig{MaxConcurrentPerWorkspace: &limit},
+			}, nil
+		},
+		prepare: func(context.Context, *model.RuntimeConfig, model.RunScope) (*model.SolvePreparation, error) {
+			return &model.SolvePreparation{}, nil
+		},
+		execute: func(ctx context.Context, _ *model.SolvePreparation, _ *model.RuntimeConfig) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	first := env.startTaskRun(t, "workspace-limit-first", nil)
+	_, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, env.workflowSlug, apicore.TaskRunRequest{
+		Workspace:        env.workspaceRoot,
+		PresentationMode: defaultPresentationMode,
+		RuntimeOverrides: rawJSON(t, `{"run_id":"workspace-limit-second"}`),
+	})
+	var problem *apicore.Problem
+	if !errors.As(err, &problem) {
+		t.Fatalf("StartTaskRun(second) error = %v, want problem", err)
+	}
+	if problem.Status != http.StatusTooManyRequests || problem.Code != "workspace_concurrency_limit" {
+		t.Fatalf("problem = status:%d code:%q, want 429 workspace_concurrency_limit", problem.Status, problem.Code)
+	}
+	if problem.RetryAfter != workspaceLimitRetryAfter {
+		t.Fatalf("problem.RetryAfter = %s, want %s", problem.RetryAfter, workspaceLimitRetryAfter)
+	}
+
+	close(release)
+	waitForRun(t, env.globalDB, first.RunID, func(row globaldb.Run) bool {
+		return isTerminalRunStatus(row.Status)
+	})
+	third := env.startTaskRun(t, "workspace-limit-third", nil)
+	waitForRun(t, env.globalDB, third.RunID, func(row globaldb.Run) bool {
+		return isTerminalRunStatus(row.Status)
+	})
+}
+
+func TestRunManagerQueuedWorkflowStartLeavesWorkspaceSlotFree(t *testing.T) {
+	release := make(chan struct{})
+	workflowLimit := 1
+	workspaceLimit := 2
+	policy := workspacecfg.WorkflowLimitPolicyQueue
+	env := newRunManagerTestEnv(t, runManagerTestDeps{
+		loadProjectConfig: func(context.Context, string) (workspacecfg.ProjectConfig, error) {
+			return workspacecfg.ProjectConfig{
+				Runs: workspacecfg.RunsConfig{
+					MaxConcurrentPerWorkflow:  &workflowLimit,
+					WorkflowLimitPolicy:       &policy,
+					MaxConcurrentPerWorkspace: &workspaceLimit,
+					WorkspaceLimitPolicy:      &policy,
+				},
+			}, nil
+		},
+		prepare: func(context.Context, *model.RuntimeConfig, model.RunScope) (*model.SolvePreparation, error) {
+			return &model.SolvePreparation{}, nil
+		},
+		execute: func(ctx context.Context, _ *model.SolvePreparation, _ *model.RuntimeConfig) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+	otherSlug := "other-workflow"
+	env.writeWorkflowFile(t, otherSlug, "task_01.md", daemonTaskBody("pending", "Other workflow"))
+
+	first := env.startTaskRun(t, "slot-order-first", nil)
+	queued := make(chan error, 1)
+	go func() {
+		_, err := env.manager.StartTaskRun(
+			context.Background(),
+			env.workspaceRoot,
+			env.workflowSlug,
+			apicore.TaskRunRequest{
+				Workspace:        env.workspaceRoot,
+				PresentationMode: defaultPresentationMode,
+				RuntimeOverrides: rawJSON(t, `{"run_id":"slot-order-queued"}`),
+			},
+		)
+		queued <- err
+	}()
+	select {
+	case err := <-queued:
+		t.Fatalf("queued StartTaskRun() returned early with %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	started := make(chan error, 1)
+	go func() {
+		_, err := env.manager.StartTaskRun(context.Background(), env.workspaceRoot, otherSlug, apicore.TaskRunRequest{
+			Workspace:        env.workspaceRoot,
+			PresentationMode: defaultPresentationMode,
+			RuntimeOverrides: rawJSON(t, `{"run_id":"slot-order-other"}`),
+		})
+		started <- err
+	}()
+	select {
+	case err := <-started:
+		if err != nil {
+			t.Fatalf("StartTaskRun(other workflow) error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("StartTaskRun(other workflow) blocked behind the queued start of a saturated workflow")
+	}
+
+	close(release)
+	select {
+	case err := <-queued:
+		if err != nil {
+			t.Fatalf("queued StartTaskRun() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued StartTaskRun() did not start after the slots freed")
+	}
+	for _, runID := range []string{first.RunID, "slot-order-queued", "slot-order-other"} {
+		waitForRun(t, env.globalDB, runID, func(row globaldb.Run) bool {
+			return isTerminalRunStatus(row.Status)
+		})
+	}
+}
+
+func newWorkflowLimitTestEnv(t *testing.T, policy string, release <-chan struct{}) *runManagerTestEnv {
+	t.Helper()
+
+	limit := 1
+	return newRunManagerTestEnv(t, runManagerTestDeps{
+		loadProjectConfig: func(context.Context, string) (workspacecfg.ProjectConfig, error) {
+			return workspacecfg.ProjectConfig{
+				Runs: workspacecfg.RunsConfig{
+					MaxConcurrentPerWorkflow: &limit,
+					WorkflowLimitPolicy:      &policy,
+				},
+			}, nil
+		},
+		prepare: func(context.Context, *model.RuntimeConfig, model.RunScope) (*model.SolvePreparation, error) {
+			return &model.SolvePreparation{}, nil
+		},
+		execute: func(ctx context.Context, _ *model.SolvePreparation, _ *model.RuntimeConfig) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
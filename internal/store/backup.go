@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BackupSQLiteDatabase writes a consistent snapshot of an open database to
+// target with VACUUM INTO. It is safe while other connections keep writing,
+// and it refuses to overwrite an existing file.
+func BackupSQLiteDatabase(ctx context.Context, db *sql.DB, target string) error {
+	if db == nil {
+		return errors.New("store: backup database is required")
+	}
+	cleanTarget := strings.TrimSpace(target)
+	if cleanTarget == "" {
+		return errors.New("store: backup path is required")
+	}
+	if _, err := os.Stat(cleanTarget); err == nil {
+		return fmt.Errorf("store: backup path %q already exists", cleanTarget)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("store: stat backup path %q: %w", cleanTarget, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cleanTarget), 0o755); err != nil {
+		return fmt.Errorf("store: create backup directory for %q: %w", cleanTarget, err)
+	}
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", cleanTarget); err != nil {
+		return fmt.Errorf("store: back up sqlite database to %q: %w", cleanTarget, err)
+	}
+	return nil
+}
+
+// BackupSQLiteFile snapshots the database file at source into target through
+// a read-only connection, so the source is never modified.
+func BackupSQLiteFile(ctx context.Context, source string, target string) error {
+	cleanSource := strings.TrimSpace(source)
+	if cleanSource == "" {
+		return errors.New("store: backup source path is required")
+	}
+	if _, err := os.Stat(cleanSource); err != nil {
+		return fmt.Errorf("store: stat backup source %q: %w", cleanSource, err)
+	}
+	db, err := sql.Open(sqliteDriverName, readOnlyFileSQLiteDSN(cleanSource, false))
+	if err != nil {
+		return fmt.Errorf("store: open backup source %q read-only: %w", cleanSource, err)
+	}
+	defer closeQuietly(db)
+	return BackupSQLiteDatabase(ctx, db, target)
+}
+
+// RestoreSQLiteDatabase replaces the database at target with the backup at
+// source. The backup must pass an integrity check first. The replaced files
+// are moved aside and their new path is returned; it is empty when target did
+// not exist yet. Callers must make sure nothing holds target open.
+func RestoreSQLiteDatabase(ctx context.Context, source string, target string) (string, error) {
+	cleanSource := strings.TrimSpace(source)
+	cleanTarget := strings.TrimSpace(target)
+	if cleanSource == "" || cleanTarget == "" {
+		return "", errors.New("store: restore source and target paths are required")
+	}
+	if err := verifySQLiteBackup(ctx, cleanSource); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(cleanTarget), 0o755); err != nil {
+		return "", fmt.Errorf("store: create database directory for %q: %w", cleanTarget, err)
+	}
+
+	staged, err := stageSQLiteRestore(cleanSource, cleanTarget)
+	if err != nil {
+		return "", err
+	}
+	replacedPath, err := moveAsideSQLiteDatabase(cleanTarget)
+	if err != nil {
+		_ = os.Remove(staged)
+		return "", err
+	}
+	if err := os.Rename(staged, cleanTarget); err != nil {
+		_ = os.Remove(staged)
+		return replacedPath, fmt.Errorf("store: install restored database %q: %w", cleanTarget, err)
+	}
+	return replacedPath, nil
+}
+
+func verifySQLiteBackup(ctx context.Context, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("store: stat backup %q: %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("store: backup %q is a directory", path)
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path), RawQuery: "mode=ro"}
+	db, err := sql.Open(sqliteDriverName, u.String())
+	if err != nil {
+		return fmt.Errorf("store: open backup %q: %w", path, err)
+	}
+	defer closeQuietly(db)
+
+	result, err := querySingleString(ctx, db, "PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("store: check backup %q: %w", path, err)
+	}
+	if !strings.EqualFold(result, "ok") {
+		return fmt.Errorf("store: backup %q failed integrity check: %s", path, result)
+	}
+	return nil
+}
+
+// stageSQLiteRestore copies the backup next to target so the final install is
+// a same-directory rename.
+func stageSQLiteRestore(source string, target string) (string, error) {
+	in, err := os.Open(source)
+	if err != nil {
+		return "", fmt.Errorf("store: open backup %q: %w", source, err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.CreateTemp(filepath.Dir(target), filepath.Base(target)+".restore-*")
+	if err != nil {
+		return "", fmt.Errorf("store: stage restore for %q: %w", target, err)
+	}
+	staged := out.Name()
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(staged)
+		return "", fmt.Errorf("store: copy backup %q: %w", source, err)
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		_ = os.Remove(staged)
+		return "", fmt.Errorf("store: sync staged restore %q: %w", staged, err)
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(staged)
+		return "", fmt.Errorf("store: close staged restore %q: %w", staged, err)
+	}
+	return staged, nil
+}
+
+func moveAsideSQLiteDatabase(path string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("store: stat database %q: %w", path, err)
+	}
+	asidePath := fmt.Sprintf("%s.pre-restore.%s", path, time.Now().UTC().Format("20060102T150405.000000000Z0700"))
+	if err := os.Rename(path, asidePath); err != nil {
+		return "", fmt.Errorf("store: move aside database %q: %w", path, err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := renameSQLiteCompanion(path+suffix, asidePath+suffix); err != nil {
+			return asidePath, fmt.Errorf("store: move aside database %q: %w", path+suffix, err)
+		}
+	}
+	return asidePath, nil
+}
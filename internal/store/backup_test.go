@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackupAndRestoreSQLiteDatabase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should restore the snapshot and move the replaced database aside", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "live.db")
+		backupPath := filepath.Join(dir, "backups", "live.backup.db")
+
+		db := openBackupTestDatabase(t, dbPath)
+		execBackupTestStatement(t, db, "INSERT INTO items(name) VALUES ('before')")
+		if err := BackupSQLiteDatabase(ctx, db, backupPath); err != nil {
+			t.Fatalf("BackupSQLiteDatabase() error = %v", err)
+		}
+		execBackupTestStatement(t, db, "INSERT INTO items(name) VALUES ('after')")
+		if err := CloseSQLiteDatabase(ctx, db); err != nil {
+			t.Fatalf("CloseSQLiteDatabase() error = %v", err)
+		}
+
+		replacedPath, err := RestoreSQLiteDatabase(ctx, backupPath, dbPath)
+		if err != nil {
+			t.Fatalf("RestoreSQLiteDatabase() error = %v", err)
+		}
+		if !strings.HasPrefix(replacedPath, dbPath+".pre-restore.") {
+			t.Fatalf("replaced path = %q, want a .pre-restore sibling of %q", replacedPath, dbPath)
+		}
+		if _, err := os.Stat(replacedPath); err != nil {
+			t.Fatalf("stat replaced database: %v", err)
+		}
+
+		restored := openBackupTestDatabase(t, dbPath)
+		defer closeQuietly(restored)
+		if got := countBackupTestRows(t, restored); got != 1 {
+			t.Fatalf("restored rows = %d, want 1", got)
+		}
+	})
+
+	t.Run("Should snapshot a database file through a read-only connection", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "live.db")
+		db := openBackupTestDatabase(t, dbPath)
+		execBackupTestStatement(t, db, "INSERT INTO items(name) VALUES ('archived')")
+		closeQuietly(db)
+
+		archivePath := filepath.Join(dir, "archive", "live.db")
+		if err := BackupSQLiteFile(ctx, dbPath, archivePath); err != nil {
+			t.Fatalf("BackupSQLiteFile() error = %v", err)
+		}
+		archived := openBackupTestDatabase(t, archivePath)
+		defer closeQuietly(archived)
+		if got := countBackupTestRows(t, archived); got != 1 {
+			t.Fatalf("archived rows = %d, want 1", got)
+		}
+	})
+
+	t.Run("Should refuse to overwrite an existing backup file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		db := openBackupTestDatabase(t, filepath.Join(dir, "live.db"))
+		defer closeQuietly(db)
+		backupPath := filepath.Join(dir, "existing.db")
+		if err := os.WriteFile(backupPath, []byte("keep"), 0o600); err != nil {
+			t.Fatalf("write existing backup: %v", err)
+		}
+
+		err := BackupSQLiteDatabase(context.Background(), db, backupPath)
+		if err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Fatalf("BackupSQLiteDatabase() error = %v, want already exists", err)
+		}
+	})
+
+	t.Run("Should reject a backup that is not a SQLite database", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "live.db")
+		closeQuietly(openBackupTestDatabase(t, dbPath))
+		backupPath := filepath.Join(dir, "garbage.db")
+		if err := os.WriteFile(backupPath, []byte(strings.Repeat("not sqlite ", 100)), 0o600); err != nil {
+			t.Fatalf("write garbage backup: %v", err)
+		}
+
+		if _, err := RestoreSQLiteDatabase(context.Background(), backupPath, dbPath); err == nil {
+			t.Fatal("RestoreSQLiteDatabase() error = nil, want rejection")
+		}
+		if _, err := os.Stat(dbPath); err != nil {
+			t.Fatalf("live database must stay in place after a rejected restore: %v", err)
+		}
+	})
+}
+
+func openBackupTestDatabase(t *testing.T, path string) *sql.DB {
+	t.Helper()
+
+	db, err := OpenSQLiteDatabase(context.Background(), path, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS items (name TEXT NOT NULL)")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("OpenSQLiteDatabase(%q) error = %v", path, err)
+	}
+	return db
+}
+
+func execBackupTestStatement(t *testing.T, db *sql.DB, stmt string) {
+	t.Helper()
+
+	if _, err := db.ExecContext(context.Background(), stmt); err != nil {
+		t.Fatalf("exec %q: %v", stmt, err)
+	}
+}
+
+func countBackupTestRows(t *testing.T, db *sql.DB) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	return count
+}
@@ -404,7 +404,7 @@ func (g *GlobalDB) MarkWorkflowArchived(
 	}
 	archivedAt = archivedAt.UTC()
 
-	result, err := g.db.ExecContext(
+	result, err := g.execWrite(
 		ctx,
 		`UPDATE workflows
 		 SET archived_at = ?, updated_at = ?
@@ -26,6 +26,7 @@ type GlobalDB struct {
 	path    string
 	now     func() time.Time
 	newID   func(string) string
+	writes  *store.WriteQueue
 	closeMu sync.Mutex
 	closed  atomic.Bool
 }
@@ -45,7 +46,8 @@ func openWithOptions(ctx context.Context, path string, opts openOptions) (*Globa
 		now: func() time.Time {
 			return time.Now().UTC()
 		},
-		newID: store.NewID,
+		newID:  store.NewID,
+		writes: store.NewWriteQueue(),
 	}
 	if opts.now != nil {
 		g.now = opts.now
@@ -100,6 +102,33 @@ func (g *GlobalDB) Path() string {
 	return g.path
 }
 
+// WriteQueueStats reports the catalog write queue depth and busy-retry counters.
+func (g *GlobalDB) WriteQueueStats() store.WriteQueueStats {
+	if g == nil {
+		return store.WriteQueueStats{}
+	}
+	return g.writes.Stats()
+}
+
+// CheckSchemaDrift compares the live catalog schema with the applied migrations.
+func (g *GlobalDB) CheckSchemaDrift(ctx context.Context) (store.SchemaDriftReport, error) {
+	if err := g.requireContext(ctx, "check schema drift"); err != nil {
+		return store.SchemaDriftReport{}, err
+	}
+	return newMigrator(migrations, g.now).CheckSchemaDrift(ctx, g.db)
+}
+
+// execWrite runs one mutating statement through the catalog write queue.
+func (g *GlobalDB) execWrite(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var result sql.Result
+	err := g.writes.Do(ctx, func(ctx context.Context) error {
+		var execErr error
+		result, execErr = g.db.ExecContext(ctx, query, args...)
+		return execErr
+	})
+	return result, err
+}
+
 func (g *GlobalDB) requireContext(ctx context.Context, action string) error {
 	if g == nil || g.db == nil || g.closed.Load() {
 		return errors.New("globaldb: database is required")
@@ -0,0 +1,123 @@
+package globaldb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/compozy/compozy/internal/store"
+)
+
+// MaintenancePause records one persisted pause on new run starts. A pause with
+// no workspace and no workflow applies to the whole daemon.
+type MaintenancePause struct {
+	WorkspaceID  string
+	WorkflowSlug string
+	Reason       string
+	PausedAt     time.Time
+}
+
+// Global reports whether the pause applies to every workspace and workflow.
+func (p MaintenancePause) Global() bool {
+	return strings.TrimSpace(p.WorkspaceID) == "" && strings.TrimSpace(p.WorkflowSlug) == ""
+}
+
+// PutMaintenancePause creates or replaces one maintenance pause.
+func (g *GlobalDB) PutMaintenancePause(ctx context.Context, pause MaintenancePause) (MaintenancePause, error) {
+	if err := g.requireContext(ctx, "put maintenance pause"); err != nil {
+		return MaintenancePause{}, err
+	}
+
+	pause.WorkspaceID = strings.TrimSpace(pause.WorkspaceID)
+	pause.WorkflowSlug = strings.TrimSpace(pause.WorkflowSlug)
+	pause.Reason = strings.TrimSpace(pause.Reason)
+	if pause.WorkflowSlug != "" && pause.WorkspaceID == "" {
+		return MaintenancePause{}, fmt.Errorf(
+			"globaldb: maintenance pause for workflow %q requires a workspace",
+			pause.WorkflowSlug,
+		)
+	}
+	if pause.PausedAt.IsZero() {
+		pause.PausedAt = g.now()
+	}
+	pause.PausedAt = pause.PausedAt.UTC()
+
+	if _, err := g.execWrite(
+		ctx,
+		`INSERT INTO maintenance_pauses (workspace_id, workflow_slug, reason, paused_at)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT(workspace_id, workflow_slug) DO UPDATE SET
+		 	reason = excluded.reason,
+		 	paused_at = excluded.paused_at`,
+		pause.WorkspaceID,
+		pause.WorkflowSlug,
+		pause.Reason,
+		store.FormatTimestamp(pause.PausedAt),
+	); err != nil {
+		return MaintenancePause{}, fmt.Errorf("globaldb: put maintenance pause: %w", err)
+	}
+	return pause, nil
+}
+
+// DeleteMaintenancePause removes one maintenance pause and reports whether it existed.
+func (g *GlobalDB) DeleteMaintenancePause(ctx context.Context, workspaceID string, workflowSlug string) (bool, error) {
+	if err := g.requireContext(ctx, "delete maintenance pause"); err != nil {
+		return false, err
+	}
+
+	result, err := g.execWrite(
+		ctx,
+		`DELETE FROM maintenance_pauses WHERE workspace_id = ? AND workflow_slug = ?`,
+		strings.TrimSpace(workspaceID),
+		strings.TrimSpace(workflowSlug),
+	)
+	if err != nil {
+		return false, fmt.Errorf("globaldb: delete maintenance pause: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("globaldb: rows affected for maintenance pause: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ListMaintenancePauses returns every persisted pause, global pause first.
+func (g *GlobalDB) ListMaintenancePauses(ctx context.Context) ([]MaintenancePause, error) {
+	if err := g.requireContext(ctx, "list maintenance pauses"); err != nil {
+		return nil, err
+	}
+
+	rows, err := g.db.QueryContext(
+		ctx,
+		`SELECT workspace_id, workflow_slug, reason, paused_at
+		 FROM maintenance_pauses
+		 ORDER BY workspace_id ASC, workflow_slug ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("globaldb: list maintenance pauses: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	pauses := make([]MaintenancePause, 0)
+	for rows.Next() {
+		var (
+			pause    MaintenancePause
+			pausedAt string
+		)
+		if err := rows.Scan(&pause.WorkspaceID, &pause.WorkflowSlug, &pause.Reason, &pausedAt); err != nil {
+			return nil, fmt.Errorf("globaldb: scan maintenance pause: %w", err)
+		}
+		pause.PausedAt, err = store.ParseTimestamp(pausedAt)
+		if err != nil {
+			return nil, fmt.Errorf("globaldb: decode maintenance pause: %w", err)
+		}
+		pauses = append(pauses, pause)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("globaldb: iterate maintenance pauses: %w", err)
+	}
+	return pauses, nil
+}
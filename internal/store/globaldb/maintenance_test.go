@@ -0,0 +1,152 @@
+package globaldb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMaintenancePausesPersistAcrossReopen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "global.db")
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	pausedAt := time.Date(2026, 4, 17, 18, 0, 0, 0, time.UTC)
+	if _, err := db.PutMaintenancePause(ctx, MaintenancePause{Reason: "upgrade", PausedAt: pausedAt}); err != nil {
+		t.Fatalf("PutMaintenancePause(global) error = %v", err)
+	}
+	if _, err := db.PutMaintenancePause(ctx, MaintenancePause{
+		WorkspaceID:  "ws-1",
+		WorkflowSlug: "billing",
+		PausedAt:     pausedAt,
+	}); err != nil {
+		t.Fatalf("PutMaintenancePause(workflow) error = %v", err)
+	}
+	if _, err := db.PutMaintenancePause(ctx, MaintenancePause{
+		Reason:   "upgrade v2",
+		PausedAt: pausedAt.Add(time.Minute),
+	}); err != nil {
+		t.Fatalf("PutMaintenancePause(global again) error = %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open(reopen) error = %v", err)
+	}
+	defer func() {
+		_ = reopened.Close()
+	}()
+
+	pauses, err := reopened.ListMaintenancePauses(ctx)
+	if err != nil {
+		t.Fatalf("ListMaintenancePauses() error = %v", err)
+	}
+	if len(pauses) != 2 {
+		t.Fatalf("pauses = %#v, want 2 rows", pauses)
+	}
+	if !pauses[0].Global() || pauses[0].Reason != "upgrade v2" || !pauses[0].PausedAt.Equal(pausedAt.Add(time.Minute)) {
+		t.Fatalf("global pause = %#v, want replaced global pause", pauses[0])
+	}
+	if pauses[1].Global() || pauses[1].WorkspaceID != "ws-1" || pauses[1].WorkflowSlug != "billing" {
+		t.Fatalf("workflow pause = %#v, want ws-1/billing", pauses[1])
+	}
+
+	removed, err := reopened.DeleteMaintenancePause(ctx, "", "")
+	if err != nil || !removed {
+		t.Fatalf("DeleteMaintenancePause(global) = %v, %v; want true, nil", removed, err)
+	}
+	removed, err = reopened.DeleteMaintenancePause(ctx, "", "")
+	if err != nil || removed {
+		t.Fatalf("DeleteMaintenancePause(global again) = %v, %v; want false, nil", removed, err)
+	}
+}
+
+func TestPutMaintenancePauseRequiresWorkspaceForWorkflow(t *testing.T) {
+	t.Parallel()
+
+	db := openTestGlobalDB(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := db.PutMaintenancePause(context.Background(), MaintenancePause{WorkflowSlug: "billing"}); err == nil {
+		t.Fatal("PutMaintenancePause(workflow without workspace) error = nil, want error")
+	}
+}
+
+func TestDeletingWorkspaceRemovesItsMaintenancePauses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name   string
+		delete func(*GlobalDB, Workspace) error
+	}{
+		{
+			name: "Should drop pauses when an empty workspace is deleted",
+			delete: func(db *GlobalDB, workspace Workspace) error {
+				deleted, err := db.DeleteWorkspaceIfNoCatalogData(ctx, workspace.ID)
+				if err == nil && !deleted {
+					t.Fatal("DeleteWorkspaceIfNoCatalogData() = false, want workspace deleted")
+				}
+				return err
+			},
+		},
+		{
+			name: "Should drop pauses when a workspace is unregistered",
+			delete: func(db *GlobalDB, workspace Workspace) error {
+				return db.Unregister(ctx, workspace.ID)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := openTestGlobalDB(t)
+			defer func() {
+				_ = db.Close()
+			}()
+
+			workspace, err := db.ResolveOrRegister(ctx, t.TempDir())
+			if err != nil {
+				t.Fatalf("ResolveOrRegister() error = %v", err)
+			}
+			for _, pause := range []MaintenancePause{
+				{},
+				{WorkspaceID: workspace.ID},
+				{WorkspaceID: workspace.ID, WorkflowSlug: "billing"},
+				{WorkspaceID: "ws-other"},
+			} {
+				if _, err := db.PutMaintenancePause(ctx, pause); err != nil {
+					t.Fatalf("PutMaintenancePause(%#v) error = %v", pause, err)
+				}
+			}
+
+			if err := tc.delete(db, workspace); err != nil {
+				t.Fatalf("delete workspace error = %v", err)
+			}
+
+			pauses, err := db.ListMaintenancePauses(ctx)
+			if err != nil {
+				t.Fatalf("ListMaintenancePauses() error = %v", err)
+			}
+			for _, pause := range pauses {
+				if pause.WorkspaceID == workspace.ID {
+					t.Fatalf("pause %#v survived workspace deletion", pause)
+				}
+			}
+			if len(pauses) != 2 {
+				t.Fatalf("pauses = %#v, want the global and ws-other pauses kept", pauses)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/compozy/compozy/internal/store"
@@ -15,6 +14,7 @@ type migration struct {
 	version    int
 	name       string
 	statements []string
+	down       []string
 }
 
 var migrations = []migration{
@@ -124,6 +124,16 @@ var migrations = []migration{
 				PRIMARY KEY (workflow_id, scope)
 			);`,
 		},
+		down: []string{
+			`DROP TABLE IF EXISTS sync_checkpoints;`,
+			`DROP TABLE IF EXISTS runs;`,
+			`DROP TABLE IF EXISTS review_issues;`,
+			`DROP TABLE IF EXISTS review_rounds;`,
+			`DROP TABLE IF EXISTS task_items;`,
+			`DROP TABLE IF EXISTS artifact_snapshots;`,
+			`DROP TABLE IF EXISTS workflows;`,
+			`DROP TABLE IF EXISTS workspaces;`,
+		},
 	},
 	{
 		version: 2,
@@ -142,6 +152,9 @@ var migrations = []migration{
 				ON runs(workflow_id)
 				WHERE workflow_id IS NOT NULL;`,
 		},
+		down: []string{
+			`DROP INDEX IF EXISTS idx_runs_workflow_id;`,
+		},
 	},
 	{
 		version: 3,
@@ -151,6 +164,11 @@ var migrations = []migration{
 			`CREATE INDEX IF NOT EXISTS idx_runs_workspace_status
 				ON runs(workspace_id, status, started_at DESC, run_id ASC);`,
 		},
+		down: []string{
+			`DROP INDEX IF EXISTS idx_runs_workspace_status;`,
+			`CREATE INDEX IF NOT EXISTS idx_runs_workspace_status
+				ON runs(workspace_id, status);`,
+		},
 	},
 	{
 		version: 4,
@@ -169,6 +187,14 @@ var migrations = []migration{
 				created_at TEXT NOT NULL
 			);`,
 		},
+		down: []string{
+			`DROP TABLE IF EXISTS artifact_bodies;`,
+			`DROP INDEX IF EXISTS idx_workspaces_filesystem_state;`,
+			`ALTER TABLE workspaces DROP COLUMN last_sync_error;`,
+			`ALTER TABLE workspaces DROP COLUMN last_sync_at;`,
+			`ALTER TABLE workspaces DROP COLUMN last_checked_at;`,
+			`ALTER TABLE workspaces DROP COLUMN filesystem_state;`,
+		},
 	},
 	{
 		version: 5,
@@ -179,15 +205,37 @@ var migrations = []migration{
 				ON runs(parent_run_id)
 				WHERE parent_run_id <> '';`,
 		},
+		down: []string{
+			`DROP INDEX IF EXISTS idx_runs_parent_run_id;`,
+			`ALTER TABLE runs DROP COLUMN parent_run_id;`,
+		},
+	},
+	{
+		version: 6,
+		name:    "maintenance_pauses",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS maintenance_pauses (
+				workspace_id  TEXT NOT NULL DEFAULT '',
+				workflow_slug TEXT NOT NULL DEFAULT '',
+				reason        TEXT NOT NULL DEFAULT '',
+				paused_at     TEXT NOT NULL,
+				PRIMARY KEY (workspace_id, workflow_slug)
+			);`,
+		},
+		down: []string{
+			`DROP TABLE IF EXISTS maintenance_pauses;`,
+		},
+	},
+	{
+		version: 7,
+		name:    "runs_labels",
+		statements: []string{
+			`ALTER TABLE runs ADD COLUMN labels TEXT NOT NULL DEFAULT '{}';`,
+		},
+		down: []string{
+			`ALTER TABLE runs DROP COLUMN labels;`,
+		},
 	},
-}
-
-var migrationTableStatements = []string{
-	`CREATE TABLE IF NOT EXISTS schema_migrations (
-		version    INTEGER PRIMARY KEY,
-		name       TEXT NOT NULL,
-		applied_at TEXT NOT NULL
-	);`,
 }
 
 // ErrSchemaTooNew reports that a database carries a migration newer than this binary understands.
@@ -211,125 +259,32 @@ func (e SchemaTooNewError) Is(target error) bool {
 	return target == ErrSchemaTooNew
 }
 
-func applyMigrations(ctx context.Context, db *sql.DB, now func() time.Time) error {
-	if ctx == nil {
-		return errors.New("globaldb: migrate context is required")
-	}
-	if db == nil {
-		return errors.New("globaldb: migrate database is required")
-	}
-	if now == nil {
-		now = func() time.Time {
-			return time.Now().UTC()
-		}
-	}
-
-	if err := store.EnsureSchema(ctx, db, migrationTableStatements); err != nil {
-		return fmt.Errorf("globaldb: ensure schema migrations table: %w", err)
-	}
-
-	applied, err := loadAppliedMigrations(ctx, db)
-	if err != nil {
-		return err
-	}
-
-	latestKnown := migrations[len(migrations)-1].version
-	if applied.highestVersion > latestKnown {
-		return SchemaTooNewError{
-			CurrentVersion: applied.highestVersion,
-			KnownVersion:   latestKnown,
-		}
-	}
-
-	for _, item := range migrations {
-		if applied.versions[item.version] {
-			continue
-		}
-		if err := applyMigration(ctx, db, item, now); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-type appliedMigrationState struct {
-	versions       map[int]bool
-	highestVersion int
+// Migrator exposes the global catalog migrations for offline tooling such as
+// `compozy db migrate`. It does not apply anything until called.
+func Migrator() *store.Migrator {
+	return newMigrator(migrations, nil)
 }
 
-func loadAppliedMigrations(ctx context.Context, db *sql.DB) (appliedMigrationState, error) {
-	rows, err := db.QueryContext(
-		ctx,
-		`SELECT version FROM schema_migrations ORDER BY version ASC`,
-	)
-	if err != nil {
-		return appliedMigrationState{}, fmt.Errorf("globaldb: query schema migrations: %w", err)
-	}
-	defer func() {
-		_ = rows.Close()
-	}()
-
-	state := appliedMigrationState{versions: make(map[int]bool)}
-	for rows.Next() {
-		var version int
-		if err := rows.Scan(&version); err != nil {
-			return appliedMigrationState{}, fmt.Errorf("globaldb: scan schema migration: %w", err)
-		}
-		state.versions[version] = true
-		if version > state.highestVersion {
-			state.highestVersion = version
-		}
+func newMigrator(items []migration, now func() time.Time) *store.Migrator {
+	converted := make([]store.Migration, 0, len(items))
+	for _, item := range items {
+		converted = append(converted, store.Migration{
+			Version: item.version,
+			Name:    item.name,
+			Up:      item.statements,
+			Down:    item.down,
+		})
 	}
-	if err := rows.Err(); err != nil {
-		return appliedMigrationState{}, fmt.Errorf("globaldb: iterate schema migrations: %w", err)
-	}
-
-	return state, nil
+	return store.NewMigrator(store.MigratorConfig{
+		Scope:      "globaldb",
+		Migrations: converted,
+		Now:        now,
+		SchemaTooNew: func(current int, known int) error {
+			return SchemaTooNewError{CurrentVersion: current, KnownVersion: known}
+		},
+	})
 }
 
-func applyMigration(ctx context.Context, db *sql.DB, item migration, now func() time.Time) (retErr error) {
-	tx, err := db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("globaldb: begin migration %d: %w", item.version, err)
-	}
-
-	committed := false
-	defer func() {
-		if !committed {
-			if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
-				retErr = errors.Join(
-					retErr,
-					fmt.Errorf("globaldb: rollback migration %d: %w", item.version, rollbackErr),
-				)
-			}
-		}
-	}()
-
-	for _, stmt := range item.statements {
-		if _, err := tx.ExecContext(ctx, stmt); err != nil {
-			return fmt.Errorf(
-				"globaldb: apply migration %d (%s): %w",
-				item.version,
-				strings.TrimSpace(item.name),
-				err,
-			)
-		}
-	}
-
-	if _, err := tx.ExecContext(
-		ctx,
-		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
-		item.version,
-		strings.TrimSpace(item.name),
-		store.FormatTimestamp(now()),
-	); err != nil {
-		return fmt.Errorf("globaldb: record migration %d: %w", item.version, err)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("globaldb: commit migration %d: %w", item.version, err)
-	}
-	committed = true
-	return nil
+func applyMigrations(ctx context.Context, db *sql.DB, now func() time.Time) error {
+	return newMigrator(migrations, now).Up(ctx, db)
 }
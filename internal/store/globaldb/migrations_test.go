@@ -72,7 +72,7 @@ func TestApplyMigrationsRejectsSchemaTooNew(t *testing.T) {
 		context.Background(),
 		filepath.Join(t.TempDir(), "future.db"),
 		func(ctx context.Context, db *sql.DB) error {
-			if err := store.EnsureSchema(ctx, db, migrationTableStatements); err != nil {
+			if err := store.EnsureSchema(ctx, db, legacyMigrationTableStatements); err != nil {
 				return err
 			}
 			_, err := db.ExecContext(
@@ -151,7 +151,7 @@ func TestApplyMigrationReturnsStatementErrors(t *testing.T) {
 		context.Background(),
 		filepath.Join(t.TempDir(), "broken.db"),
 		func(ctx context.Context, db *sql.DB) error {
-			return store.EnsureSchema(ctx, db, migrationTableStatements)
+			return store.EnsureSchema(ctx, db, legacyMigrationTableStatements)
 		},
 	)
 	if err != nil {
@@ -161,18 +161,28 @@ func TestApplyMigrationReturnsStatementErrors(t *testing.T) {
 		_ = sqlDB.Close()
 	}()
 
-	err = applyMigration(context.Background(), sqlDB, migration{
+	err = newMigrator([]migration{{
 		version:    2,
 		name:       "broken",
 		statements: []string{"CREATE TABL definitely_invalid ("},
-	}, func() time.Time {
+	}}, func() time.Time {
 		return time.Date(2026, 4, 17, 19, 15, 0, 0, time.UTC)
-	})
+	}).Up(context.Background(), sqlDB)
 	if err == nil {
 		t.Fatal("applyMigration(broken) error = nil, want non-nil")
 	}
 }
 
+// legacyMigrationTableStatements is the schema_migrations layout written before
+// checksums were recorded.
+var legacyMigrationTableStatements = []string{
+	`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	);`,
+}
+
 type migrationRow struct {
 	Version   int
 	Name      string
@@ -269,3 +279,69 @@ func openTestGlobalDB(t *testing.T) *GlobalDB {
 	}
 	return db
 }
+
+func TestMigrationsRoundTripThroughEveryDownStep(t *testing.T) {
+	t.Parallel()
+
+	db := openTestGlobalDB(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	ctx := context.Background()
+	migrator := newMigrator(migrations, db.now)
+	want := loadSchemaSnapshot(t, db.db)
+	for i := len(migrations) - 1; i >= 0; i-- {
+		target := 0
+		if i > 0 {
+			target = migrations[i-1].version
+		}
+		if err := migrator.MigrateTo(ctx, db.db, target); err != nil {
+			t.Fatalf("MigrateTo(%d): %v", target, err)
+		}
+		if err := migrator.Up(ctx, db.db); err != nil {
+			t.Fatalf("Up() after MigrateTo(%d): %v", target, err)
+		}
+		if got := loadSchemaSnapshot(t, db.db); !reflect.DeepEqual(got, want) {
+			t.Fatalf("schema after down to %d and up differs\nwant: %#v\ngot:  %#v", target, want, got)
+		}
+	}
+
+	if err := migrator.MigrateTo(ctx, db.db, 0); err != nil {
+		t.Fatalf("MigrateTo(0): %v", err)
+	}
+	got := loadSchemaSnapshot(t, db.db)
+	if len(got) != 1 {
+		t.Fatalf("schema after full rollback = %#v, want only schema_migrations", got)
+	}
+}
+
+func TestCheckSchemaDriftReportsManualHotfixes(t *testing.T) {
+	t.Parallel()
+
+	db := openTestGlobalDB(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	ctx := context.Background()
+	report, err := db.CheckSchemaDrift(ctx)
+	if err != nil {
+		t.Fatalf("CheckSchemaDrift(): %v", err)
+	}
+	if !report.Clean() || report.Version != migrations[len(migrations)-1].version {
+		t.Fatalf("fresh catalog report = %#v, want clean at latest version", report)
+	}
+
+	if _, err := db.db.ExecContext(ctx, `CREATE INDEX idx_runs_hotfix ON runs(mode)`); err != nil {
+		t.Fatalf("create hotfix index: %v", err)
+	}
+	report, err = db.CheckSchemaDrift(ctx)
+	if err != nil {
+		t.Fatalf("CheckSchemaDrift() after hotfix: %v", err)
+	}
+	want := store.SchemaDrift{Kind: store.SchemaDriftUnexpected, Object: "index", Name: "idx_runs_hotfix"}
+	if len(report.Drifts) != 1 || report.Drifts[0] != want {
+		t.Fatalf("drifts = %#v, want %#v", report.Drifts, want)
+	}
+}
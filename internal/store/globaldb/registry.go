@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -103,6 +105,7 @@ type ListRunsOptions struct {
 	Status      string
 	Statuses    []string
 	Mode        string
+	Labels      map[string]string
 	Limit       int
 }
 
@@ -125,6 +128,7 @@ type Run struct {
 	EndedAt          *time.Time
 	ErrorText        string
 	RequestID        string
+	Labels           map[string]string
 }
 
 // ActiveRunsError reports how many active runs blocked a workspace unregister.
@@ -283,16 +287,11 @@ func (g *GlobalDB) Unregister(ctx context.Context, idOrPath string) error {
 		return ActiveRunsError{WorkspaceID: workspace.ID, ActiveRuns: activeRuns}
 	}
 
-	result, err := g.db.ExecContext(ctx, `DELETE FROM workspaces WHERE id = ?`, workspace.ID)
+	deleted, err := g.deleteWorkspace(ctx, workspace.ID, `DELETE FROM workspaces WHERE id = ?`, workspace.ID)
 	if err != nil {
-		return fmt.Errorf("globaldb: delete workspace %q: %w", workspace.ID, err)
-	}
-
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("globaldb: rows affected for workspace %q: %w", workspace.ID, err)
+		return err
 	}
-	if affected == 0 {
+	if !deleted {
 		return ErrWorkspaceNotFound
 	}
 
@@ -341,7 +340,7 @@ func (g *GlobalDB) UpdateWorkspaceState(ctx context.Context, update WorkspaceSta
 		lastSyncErrorValue = strings.TrimSpace(*update.LastSyncError)
 	}
 
-	result, err := g.db.ExecContext(
+	result, err := g.execWrite(
 		ctx,
 		`UPDATE workspaces
 		 SET filesystem_state = ?,
@@ -399,7 +398,8 @@ func (g *GlobalDB) WorkspaceCatalogStats(ctx context.Context, workspaceID string
 	return stats, nil
 }
 
-// DeleteWorkspaceIfNoCatalogData removes an empty workspace registry row.
+// DeleteWorkspaceIfNoCatalogData removes an empty workspace registry row
+// together with its maintenance pauses.
 func (g *GlobalDB) DeleteWorkspaceIfNoCatalogData(ctx context.Context, workspaceID string) (bool, error) {
 	if err := g.requireContext(ctx, "delete empty workspace"); err != nil {
 		return false, err
@@ -410,8 +410,9 @@ func (g *GlobalDB) DeleteWorkspaceIfNoCatalogData(ctx context.Context, workspace
 		return false, errors.New("globaldb: workspace id is required")
 	}
 
-	result, err := g.db.ExecContext(
+	return g.deleteWorkspace(
 		ctx,
+		workspaceID,
 		`DELETE FROM workspaces
 		 WHERE id = ?
 		   AND NOT EXISTS (SELECT 1 FROM workflows WHERE workspace_id = ?)
@@ -420,14 +421,51 @@ func (g *GlobalDB) DeleteWorkspaceIfNoCatalogData(ctx context.Context, workspace
 		workspaceID,
 		workspaceID,
 	)
-	if err != nil {
-		return false, fmt.Errorf("globaldb: delete empty workspace %q: %w", workspaceID, err)
-	}
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return false, fmt.Errorf("globaldb: rows affected for empty workspace %q: %w", workspaceID, err)
-	}
-	return affected > 0, nil
+}
+
+// deleteWorkspace runs one workspace delete statement and, when it removed the
+// row, drops the workspace's maintenance pauses in the same transaction.
+// maintenance_pauses has no foreign key to workspaces, so nothing else would.
+func (g *GlobalDB) deleteWorkspace(ctx context.Context, workspaceID string, query string, args ...any) (bool, error) {
+	deleted := false
+	err := g.writes.Do(ctx, func(ctx context.Context) error {
+		tx, err := g.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("globaldb: begin delete workspace %q: %w", workspaceID, err)
+		}
+		committed := false
+		defer func() {
+			if !committed {
+				rollbackPendingTx(tx)
+			}
+		}()
+
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("globaldb: delete workspace %q: %w", workspaceID, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("globaldb: rows affected for workspace %q: %w", workspaceID, err)
+		}
+		if affected > 0 {
+			if _, err := tx.ExecContext(
+				ctx,
+				`DELETE FROM maintenance_pauses WHERE workspace_id = ?`,
+				workspaceID,
+			); err != nil {
+				return fmt.Errorf("globaldb: delete maintenance pauses for workspace %q: %w", workspaceID, err)
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("globaldb: commit delete workspace %q: %w", workspaceID, err)
+		}
+		committed = true
+		deleted = affected > 0
+		return nil
+	})
+	return deleted, err
 }
 
 // PutWorkflow inserts or updates one workflow identity row.
@@ -564,12 +602,17 @@ func (g *GlobalDB) PutRun(ctx context.Context, run Run) (Run, error) {
 		run.StartedAt = g.now()
 	}
 
-	_, err := g.db.ExecContext(
+	labels, err := encodeRunLabels(run.Labels)
+	if err != nil {
+		return Run{}, err
+	}
+
+	_, err = g.execWrite(
 		ctx,
 		`INSERT INTO runs (
 			run_id, workspace_id, workflow_id, mode, status, presentation_mode,
-			started_at, ended_at, error_text, parent_run_id, request_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			started_at, ended_at, error_text, parent_run_id, request_id, labels
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		run.RunID,
 		run.WorkspaceID,
 		store.NullableString(stringValue(run.WorkflowID)),
@@ -581,6 +624,7 @@ func (g *GlobalDB) PutRun(ctx context.Context, run Run) (Run, error) {
 		strings.TrimSpace(run.ErrorText),
 		run.ParentRunID,
 		strings.TrimSpace(run.RequestID),
+		labels,
 	)
 	if err != nil {
 		if isDuplicateRunError(err) {
@@ -623,7 +667,12 @@ func (g *GlobalDB) UpdateRun(ctx context.Context, run Run) (Run, error) {
 		run.StartedAt = g.now()
 	}
 
-	result, err := g.db.ExecContext(
+	labels, err := encodeRunLabels(run.Labels)
+	if err != nil {
+		return Run{}, err
+	}
+
+	result, err := g.execWrite(
 		ctx,
 		`UPDATE runs
 		 SET workspace_id = ?,
@@ -635,7 +684,8 @@ func (g *GlobalDB) UpdateRun(ctx context.Context, run Run) (Run, error) {
 		     ended_at = ?,
 		     error_text = ?,
 		     parent_run_id = ?,
-		     request_id = ?
+		     request_id = ?,
+		     labels = ?
 		 WHERE run_id = ?`,
 		run.WorkspaceID,
 		store.NullableString(stringValue(run.WorkflowID)),
@@ -647,6 +697,7 @@ func (g *GlobalDB) UpdateRun(ctx context.Context, run Run) (Run, error) {
 		strings.TrimSpace(run.ErrorText),
 		run.ParentRunID,
 		strings.TrimSpace(run.RequestID),
+		labels,
 		run.RunID,
 	)
 	if err != nil {
@@ -673,7 +724,7 @@ func (g *GlobalDB) GetRun(ctx context.Context, runID string) (Run, error) {
 	row := g.db.QueryRowContext(
 		ctx,
 		`SELECT run_id, workspace_id, workflow_id, mode, status, presentation_mode,
-		        started_at, ended_at, error_text, parent_run_id, request_id
+		        started_at, ended_at, error_text, parent_run_id, request_id, labels
 		 FROM runs
 		 WHERE run_id = ?`,
 		strings.TrimSpace(runID),
@@ -701,7 +752,7 @@ func (g *GlobalDB) ListRuns(ctx context.Context, opts ListRunsOptions) ([]Run, e
 
 	query := `
 		SELECT run_id, workspace_id, workflow_id, mode, status, presentation_mode,
-		       started_at, ended_at, error_text, parent_run_id, request_id
+		       started_at, ended_at, error_text, parent_run_id, request_id, labels
 		FROM runs
 		WHERE 1 = 1`
 	args := make([]any, 0, 4)
@@ -726,6 +777,10 @@ func (g *GlobalDB) ListRuns(ctx context.Context, opts ListRunsOptions) ([]Run, e
 		query += ` AND mode = ?`
 		args = append(args, mode)
 	}
+	for _, key := range slices.Sorted(maps.Keys(opts.Labels)) {
+		query += ` AND EXISTS (SELECT 1 FROM json_each(runs.labels) WHERE key = ? AND value = ?)`
+		args = append(args, key, opts.Labels[key])
+	}
 	query += ` ORDER BY started_at DESC, run_id ASC LIMIT ?`
 	args = append(args, limit)
 
@@ -768,7 +823,7 @@ func (g *GlobalDB) registerResolvedWorkspace(
 		UpdatedAt:       now,
 	}
 
-	result, err := g.db.ExecContext(
+	result, err := g.execWrite(
 		ctx,
 		`INSERT OR IGNORE INTO workspaces (
 			id, root_dir, name, filesystem_state, last_checked_at, last_sync_error, created_at, updated_at
@@ -952,7 +1007,7 @@ func (g *GlobalDB) insertWorkflow(ctx context.Context, workflow Workflow) (Workf
 		workflow.UpdatedAt = workflow.CreatedAt
 	}
 
-	_, err := g.db.ExecContext(
+	_, err := g.execWrite(
 		ctx,
 		`INSERT INTO workflows (
 			id, workspace_id, slug, archived_at, last_synced_at, created_at, updated_at
@@ -992,7 +1047,7 @@ func (g *GlobalDB) updateWorkflow(ctx context.Context, workflow Workflow) (Workf
 		workflow.UpdatedAt = g.now()
 	}
 
-	result, err := g.db.ExecContext(
+	result, err := g.execWrite(
 		ctx,
 		`UPDATE workflows
 		 SET workspace_id = ?, slug = ?, archived_at = ?, last_synced_at = ?, updated_at = ?
@@ -1077,6 +1132,7 @@ func scanRun(scanner rowScanner) (Run, error) {
 		workflowIDRaw sql.NullString
 		endedAtRaw    sql.NullString
 		startedAtRaw  string
+		labelsRaw     string
 	)
 	if err := scanner.Scan(
 		&run.RunID,
@@ -1090,6 +1146,7 @@ func scanRun(scanner rowScanner) (Run, error) {
 		&run.ErrorText,
 		&run.ParentRunID,
 		&run.RequestID,
+		&labelsRaw,
 	); err != nil {
 		return Run{}, err
 	}
@@ -1113,10 +1170,38 @@ func scanRun(scanner rowScanner) (Run, error) {
 	run.ErrorText = strings.TrimSpace(run.ErrorText)
 	run.ParentRunID = strings.TrimSpace(run.ParentRunID)
 	run.RequestID = strings.TrimSpace(run.RequestID)
+	labels, err := decodeRunLabels(labelsRaw)
+	if err != nil {
+		return Run{}, err
+	}
+	run.Labels = labels
 
 	return run, nil
 }
 
+func encodeRunLabels(labels map[string]string) (string, error) {
+	if len(labels) == 0 {
+		return "{}", nil
+	}
+	payload, err := json.Marshal(labels)
+	if err != nil {
+		return "", fmt.Errorf("globaldb: encode run labels: %w", err)
+	}
+	return string(payload), nil
+}
+
+func decodeRunLabels(raw string) (map[string]string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "{}" {
+		return nil, nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(raw), &labels); err != nil {
+		return nil, fmt.Errorf("globaldb: decode run labels: %w", err)
+	}
+	return labels, nil
+}
+
 func discoverWorkspaceRoot(ctx context.Context, path string) (string, error) {
 	rootDir, err := coreworkspace.Discover(ctx, path)
 	if err != nil {
@@ -58,7 +58,7 @@ func (g *GlobalDB) ListInterruptedRuns(ctx context.Context) ([]Run, error) {
 	rows, err := g.db.QueryContext(
 		ctx,
 		`SELECT run_id, workspace_id, workflow_id, mode, status, presentation_mode,
-		        started_at, ended_at, error_text, parent_run_id, request_id
+		        started_at, ended_at, error_text, parent_run_id, request_id, labels
 		 FROM runs
 		 WHERE status IN ('starting', 'running')
 		 ORDER BY started_at ASC, run_id ASC`,
@@ -126,6 +126,12 @@ func (g *GlobalDB) MarkRunsCrashed(ctx context.Context, updates []RunCrashUpdate
 		return nil
 	}
 
+	return g.writes.Do(ctx, func(ctx context.Context) error {
+		return g.markRunsCrashed(ctx, updates)
+	})
+}
+
+func (g *GlobalDB) markRunsCrashed(ctx context.Context, updates []RunCrashUpdate) error {
 	tx, err := g.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("globaldb: begin mark runs crashed: %w", err)
@@ -186,6 +192,56 @@ func (g *GlobalDB) MarkRunsCrashed(ctx context.Context, updates []RunCrashUpdate
 	return nil
 }
 
+// MarkStaleRunCrashed marks one starting or running run crashed. It reports
+// false when the run already left those statuses, so a run that settled
+// concurrently keeps its real outcome.
+func (g *GlobalDB) MarkStaleRunCrashed(
+	ctx context.Context,
+	runID string,
+	endedAt time.Time,
+	errorText string,
+) (bool, error) {
+	if err := g.requireContext(ctx, "mark stale run crashed"); err != nil {
+		return false, err
+	}
+	trimmedRunID := strings.TrimSpace(runID)
+	if trimmedRunID == "" {
+		return false, fmt.Errorf("globaldb: run id is required for crash update")
+	}
+	if endedAt.IsZero() {
+		endedAt = g.now()
+	}
+
+	var marked bool
+	err := g.writes.Do(ctx, func(ctx context.Context) error {
+		result, err := g.db.ExecContext(
+			ctx,
+			`UPDATE runs
+			 SET status = ?, ended_at = ?, error_text = ?
+			 WHERE run_id = ? AND status IN (?, ?)`,
+			runStatusCrashed,
+			store.FormatTimestamp(endedAt.UTC()),
+			strings.TrimSpace(errorText),
+			trimmedRunID,
+			runStatusStarting,
+			runStatusRunning,
+		)
+		if err != nil {
+			return fmt.Errorf("globaldb: update stale run %q crashed: %w", trimmedRunID, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("globaldb: rows affected for stale run %q: %w", trimmedRunID, err)
+		}
+		marked = affected > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return marked, nil
+}
+
 // ListTerminalRunsForPurge selects terminal runs in oldest-first order while
 // respecting the configured keep-count and keep-days bounds.
 func (g *GlobalDB) ListTerminalRunsForPurge(
@@ -243,7 +299,7 @@ func (g *GlobalDB) listTerminalRuns(ctx context.Context) ([]Run, error) {
 	rows, err := g.db.QueryContext(
 		ctx,
 		`SELECT run_id, workspace_id, workflow_id, mode, status, presentation_mode,
-		        started_at, ended_at, error_text, parent_run_id, request_id
+		        started_at, ended_at, error_text, parent_run_id, request_id, labels
 		 FROM runs
 		 WHERE status IN ('completed', 'failed', 'canceled', 'crashed')
 		 ORDER BY COALESCE(ended_at, started_at) ASC, run_id ASC`,
@@ -301,7 +357,7 @@ func (g *GlobalDB) DeleteRun(ctx context.Context, runID string) error {
 		return err
 	}
 
-	result, err := g.db.ExecContext(ctx, `DELETE FROM runs WHERE run_id = ?`, strings.TrimSpace(runID))
+	result, err := g.execWrite(ctx, `DELETE FROM runs WHERE run_id = ?`, strings.TrimSpace(runID))
 	if err != nil {
 		return fmt.Errorf("globaldb: delete run %q: %w", strings.TrimSpace(runID), err)
 	}
@@ -324,6 +380,12 @@ func (g *GlobalDB) DeleteRuns(ctx context.Context, runIDs []string) error {
 		return nil
 	}
 
+	return g.writes.Do(ctx, func(ctx context.Context) error {
+		return g.deleteRuns(ctx, runIDs)
+	})
+}
+
+func (g *GlobalDB) deleteRuns(ctx context.Context, runIDs []string) error {
 	tx, err := g.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("globaldb: begin delete runs: %w", err)
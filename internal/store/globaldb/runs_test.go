@@ -74,6 +74,67 @@ func TestListInterruptedRunsAndMarkRunCrashed(t *testing.T) {
 	}
 }
 
+func TestMarkStaleRunCrashedOnlyUpdatesActiveStatuses(t *testing.T) {
+	t.Parallel()
+
+	db := openTestGlobalDB(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	workspace := mustWorkspace(t, db)
+	startedAt := time.Date(2026, 4, 17, 19, 0, 0, 0, time.UTC)
+	for _, run := range []Run{
+		{
+			RunID:            "run-stale",
+			WorkspaceID:      workspace.ID,
+			Mode:             "task",
+			Status:           "running",
+			PresentationMode: "stream",
+			StartedAt:        startedAt,
+		},
+		{
+			RunID:            "run-settled",
+			WorkspaceID:      workspace.ID,
+			Mode:             "task",
+			Status:           "completed",
+			PresentationMode: "stream",
+			StartedAt:        startedAt,
+			EndedAt:          timePtr(startedAt.Add(time.Minute)),
+		},
+	} {
+		if _, err := db.PutRun(context.Background(), run); err != nil {
+			t.Fatalf("PutRun(%q) error = %v", run.RunID, err)
+		}
+	}
+
+	reapedAt := startedAt.Add(time.Hour)
+	marked, err := db.MarkStaleRunCrashed(context.Background(), "run-stale", reapedAt, "reaped")
+	if err != nil || !marked {
+		t.Fatalf("MarkStaleRunCrashed(run-stale) = %v, %v; want true, nil", marked, err)
+	}
+	row, err := db.GetRun(context.Background(), "run-stale")
+	if err != nil {
+		t.Fatalf("GetRun(run-stale) error = %v", err)
+	}
+	if row.Status != runStatusCrashed || row.ErrorText != "reaped" || row.EndedAt == nil ||
+		!row.EndedAt.Equal(reapedAt) {
+		t.Fatalf("unexpected reaped row: %#v", row)
+	}
+
+	marked, err = db.MarkStaleRunCrashed(context.Background(), "run-settled", reapedAt, "reaped")
+	if err != nil || marked {
+		t.Fatalf("MarkStaleRunCrashed(run-settled) = %v, %v; want false, nil", marked, err)
+	}
+	row, err = db.GetRun(context.Background(), "run-settled")
+	if err != nil {
+		t.Fatalf("GetRun(run-settled) error = %v", err)
+	}
+	if row.Status != runStatusCompleted {
+		t.Fatalf("settled run status = %q, want completed", row.Status)
+	}
+}
+
 func TestListTerminalRunsForPurgeRespectsKeepDaysAndKeepMaxOldestFirst(t *testing.T) {
 	t.Parallel()
 
@@ -459,3 +520,73 @@ func explainQueryPlan(t *testing.T, db *GlobalDB, query string, args ...any) str
 	}
 	return strings.Join(parts, " | ")
 }
+
+func TestRunLabelsRoundTripAndFilterListRuns(t *testing.T) {
+	t.Parallel()
+
+	db := openTestGlobalDB(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	workspace := mustWorkspace(t, db)
+	startedAt := time.Date(2026, 4, 17, 19, 0, 0, 0, time.UTC)
+	for idx, labels := range []map[string]string{
+		{"team": "core", "env": "prod"},
+		{"team": "core", "env": "staging"},
+		nil,
+	} {
+		if _, err := db.PutRun(context.Background(), Run{
+			RunID:            "run-labels-" + string(rune('a'+idx)),
+			WorkspaceID:      workspace.ID,
+			Mode:             "task",
+			Status:           runStatusRunning,
+			PresentationMode: "stream",
+			StartedAt:        startedAt.Add(time.Duration(idx) * time.Minute),
+			Labels:           labels,
+		}); err != nil {
+			t.Fatalf("PutRun(%d) error = %v", idx, err)
+		}
+	}
+
+	t.Run("Should round-trip labels through GetRun", func(t *testing.T) {
+		row, err := db.GetRun(context.Background(), "run-labels-a")
+		if err != nil {
+			t.Fatalf("GetRun() error = %v", err)
+		}
+		if want := map[string]string{"team": "core", "env": "prod"}; !reflect.DeepEqual(row.Labels, want) {
+			t.Fatalf("labels = %#v, want %#v", row.Labels, want)
+		}
+		unlabeled, err := db.GetRun(context.Background(), "run-labels-c")
+		if err != nil {
+			t.Fatalf("GetRun(unlabeled) error = %v", err)
+		}
+		if unlabeled.Labels != nil {
+			t.Fatalf("unlabeled labels = %#v, want nil", unlabeled.Labels)
+		}
+	})
+
+	t.Run("Should match every requested label", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			labels map[string]string
+			want   []string
+		}{
+			{name: "shared label", labels: map[string]string{"team": "core"}, want: []string{"run-labels-b", "run-labels-a"}},
+			{name: "all labels", labels: map[string]string{"team": "core", "env": "prod"}, want: []string{"run-labels-a"}},
+			{name: "unknown value", labels: map[string]string{"env": "dev"}, want: []string{}},
+		} {
+			listed, err := db.ListRuns(context.Background(), ListRunsOptions{
+				WorkspaceID: workspace.ID,
+				Labels:      tc.labels,
+				Limit:       10,
+			})
+			if err != nil {
+				t.Fatalf("ListRuns(%s) error = %v", tc.name, err)
+			}
+			if got := runIDs(listed); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ListRuns(%s) = %v, want %v", tc.name, got, tc.want)
+			}
+		}
+	})
+}
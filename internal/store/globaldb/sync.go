@@ -131,7 +131,7 @@ type existingArtifactSnapshot struct {
 func (g *GlobalDB) ReconcileWorkflowSync(
 	ctx context.Context,
 	input WorkflowSyncInput,
-) (result WorkflowSyncResult, retErr error) {
+) (WorkflowSyncResult, error) {
 	if err := g.requireContext(ctx, "reconcile workflow sync"); err != nil {
 		return WorkflowSyncResult{}, err
 	}
@@ -141,6 +141,23 @@ func (g *GlobalDB) ReconcileWorkflowSync(
 
 	syncedAt := normalizeSyncTimestamp(input.SyncedAt, g.now)
 
+	var result WorkflowSyncResult
+	err := g.writes.Do(ctx, func(ctx context.Context) error {
+		var syncErr error
+		result, syncErr = g.reconcileWorkflowSync(ctx, input, syncedAt)
+		return syncErr
+	})
+	if err != nil {
+		return WorkflowSyncResult{}, err
+	}
+	return result, nil
+}
+
+func (g *GlobalDB) reconcileWorkflowSync(
+	ctx context.Context,
+	input WorkflowSyncInput,
+	syncedAt time.Time,
+) (result WorkflowSyncResult, retErr error) {
 	tx, err := g.db.BeginTx(ctx, nil)
 	if err != nil {
 		return WorkflowSyncResult{}, fmt.Errorf("globaldb: begin workflow sync: %w", err)
@@ -323,7 +340,7 @@ func (g *GlobalDB) countActiveRunsForWorkflow(ctx context.Context, workflowID st
 }
 
 func (g *GlobalDB) deleteActiveWorkflowIfNoActiveRuns(ctx context.Context, workflowID string) (bool, error) {
-	result, err := g.db.ExecContext(
+	result, err := g.execWrite(
 		ctx,
 		`DELETE FROM workflows
 		 WHERE id = ?
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SharedMemoryDSN builds the DSN for a named in-memory database shared by every
+// pooled connection in this process. It uses the memdb VFS instead of
+// cache=shared so connections keep regular file locking: concurrent writers
+// wait on busy_timeout rather than failing fast with shared-cache table locks.
+func SharedMemoryDSN(name string) (string, error) {
+	cleanName := sanitizeMemoryDatabaseName(name)
+	if cleanName == "" {
+		return "", errors.New("store: memory database name is required")
+	}
+
+	u := url.URL{
+		Scheme: "file",
+		Path:   "/" + cleanName,
+	}
+	query := u.Query()
+	query.Set("vfs", "memdb")
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", defaultBusyTimeoutMS))
+	query.Add("_pragma", "foreign_keys(ON)")
+	query.Add("_txlock", "immediate")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// OpenSharedMemoryDatabase opens a named in-memory SQLite database with the
+// shared connection settings. The database lives until the handle is closed.
+func OpenSharedMemoryDatabase(
+	ctx context.Context,
+	name string,
+	initialize func(context.Context, *sql.DB) error,
+) (*sql.DB, error) {
+	dsn, err := SharedMemoryDSN(name)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(sqliteDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("store: open memory database %q: %w", name, err)
+	}
+
+	// Keep every connection idle-resident: memdb drops its contents once the
+	// last connection closes.
+	db.SetMaxOpenConns(defaultMaxOpenConns)
+	db.SetMaxIdleConns(defaultMaxOpenConns)
+	db.SetConnMaxIdleTime(0)
+	db.SetConnMaxLifetime(0)
+
+	if err := db.PingContext(ctx); err != nil {
+		closeQuietly(db)
+		return nil, fmt.Errorf("store: ping memory database %q: %w", name, err)
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d", defaultBusyTimeoutMS)); err != nil {
+		closeQuietly(db)
+		return nil, fmt.Errorf("store: configure memory database %q: %w", name, err)
+	}
+	if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys = ON"); err != nil {
+		closeQuietly(db)
+		return nil, fmt.Errorf("store: configure memory database %q: %w", name, err)
+	}
+	if initialize != nil {
+		if err := initialize(ctx, db); err != nil {
+			closeQuietly(db)
+			return nil, fmt.Errorf("store: initialize memory database %q: %w", name, err)
+		}
+	}
+	return db, nil
+}
+
+func sanitizeMemoryDatabaseName(name string) string {
+	trimmed := strings.TrimSpace(name)
+	var builder strings.Builder
+	builder.Grow(len(trimmed))
+	for _, r := range trimmed {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte('_')
+		}
+	}
+	return builder.String()
+}
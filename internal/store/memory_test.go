@@ -0,0 +1,120 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSharedMemoryDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		wantPath string
+		wantErr  bool
+	}{
+		{name: "plain name", input: "catalog", wantPath: "file:///catalog?"},
+		{name: "test name is sanitized", input: "TestStore/sub test#1", wantPath: "file:///TestStore_sub_test_1?"},
+		{name: "blank name is rejected", input: "  ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dsn, err := SharedMemoryDSN(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SharedMemoryDSN(%q) error = nil, want error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SharedMemoryDSN(%q) error = %v", tt.input, err)
+			}
+			if !strings.HasPrefix(dsn, tt.wantPath) {
+				t.Fatalf("SharedMemoryDSN(%q) = %q, want prefix %q", tt.input, dsn, tt.wantPath)
+			}
+			for _, fragment := range []string{"vfs=memdb", "_txlock=immediate", "busy_timeout"} {
+				if !strings.Contains(dsn, fragment) {
+					t.Fatalf("SharedMemoryDSN(%q) = %q, missing %q", tt.input, dsn, fragment)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenSharedMemoryDatabase(t *testing.T) {
+	t.Parallel()
+
+	createItems := func(ctx context.Context, db *sql.DB) error {
+		return EnsureSchema(ctx, db, []string{"CREATE TABLE IF NOT EXISTS items (id INTEGER PRIMARY KEY)"})
+	}
+
+	t.Run("pooled connections share one database", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db, err := OpenSharedMemoryDatabase(ctx, NewID(t.Name()), createItems)
+		if err != nil {
+			t.Fatalf("OpenSharedMemoryDatabase() error = %v", err)
+		}
+		defer closeQuietly(db)
+
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Go(func() {
+				if _, err := db.ExecContext(ctx, "INSERT INTO items (id) VALUES (?)", i); err != nil {
+					t.Errorf("insert %d: %v", i, err)
+				}
+			})
+		}
+		wg.Wait()
+
+		var count int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(1) FROM items").Scan(&count); err != nil {
+			t.Fatalf("count items: %v", err)
+		}
+		if count != 16 {
+			t.Fatalf("item count = %d, want 16", count)
+		}
+	})
+
+	t.Run("distinct names are isolated", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		first, err := OpenSharedMemoryDatabase(ctx, NewID(t.Name()), createItems)
+		if err != nil {
+			t.Fatalf("open first database: %v", err)
+		}
+		defer closeQuietly(first)
+		second, err := OpenSharedMemoryDatabase(ctx, NewID(t.Name()), nil)
+		if err != nil {
+			t.Fatalf("open second database: %v", err)
+		}
+		defer closeQuietly(second)
+
+		var count int
+		err = second.QueryRowContext(ctx, "SELECT COUNT(1) FROM sqlite_master WHERE name = 'items'").Scan(&count)
+		if err != nil {
+			t.Fatalf("inspect second database: %v", err)
+		}
+		if count != 0 {
+			t.Fatalf("second database sees %d items table(s), want isolation", count)
+		}
+	})
+
+	t.Run("initialize failure closes the handle", func(t *testing.T) {
+		t.Parallel()
+		_, err := OpenSharedMemoryDatabase(context.Background(), NewID(t.Name()), func(context.Context, *sql.DB) error {
+			return fmt.Errorf("boom")
+		})
+		if err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Fatalf("OpenSharedMemoryDatabase() error = %v, want initialize failure", err)
+		}
+	})
+}
@@ -0,0 +1,580 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMigrationChecksumMismatch reports that an applied migration no longer
+	// matches the definition compiled into this binary.
+	ErrMigrationChecksumMismatch = errors.New("store: migration checksum mismatch")
+	// ErrMigrationIrreversible reports a rollback through a migration without down statements.
+	ErrMigrationIrreversible = errors.New("store: migration is irreversible")
+	// ErrUnknownMigrationVersion reports a migration target this binary does not define.
+	ErrUnknownMigrationVersion = errors.New("store: unknown migration version")
+	// ErrUnknownAppliedMigration reports an applied migration, at or below the
+	// newest known version, that this binary does not define.
+	ErrUnknownAppliedMigration = errors.New("store: unknown applied migration")
+)
+
+// Migration is one versioned schema change. Down reverts Up; leave it empty
+// for migrations that cannot be rolled back.
+type Migration struct {
+	Version int
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// Checksum fingerprints the migration identity and forward statements.
+// Whitespace is normalized so reformatting SQL does not register as drift.
+func (m Migration) Checksum() string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d\n%s\n", m.Version, strings.TrimSpace(m.Name))
+	for _, stmt := range m.Up {
+		hash.Write([]byte(strings.Join(strings.Fields(stmt), " ")))
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// MigrationState describes one migration known to the binary, the database, or both.
+type MigrationState struct {
+	Version         int
+	Name            string
+	Checksum        string
+	AppliedChecksum string
+	AppliedAt       time.Time
+	Applied         bool
+	Known           bool
+	Reversible      bool
+}
+
+// Drifted reports whether the recorded checksum disagrees with the binary.
+func (s MigrationState) Drifted() bool {
+	return s.Applied && s.Known && s.AppliedChecksum != "" && s.AppliedChecksum != s.Checksum
+}
+
+// MigrationChecksumError carries the details of one drifted migration.
+type MigrationChecksumError struct {
+	Scope    string
+	Version  int
+	Name     string
+	Recorded string
+	Expected string
+}
+
+func (e MigrationChecksumError) Error() string {
+	return fmt.Sprintf(
+		"%s: migration %d (%s) checksum drift: database recorded %s, binary expects %s; "+
+			"the schema was migrated by a different compozy build. "+
+			"Inspect it with `compozy db migrate status` and reopen it with the build that applied it, "+
+			"or restore a copy taken before the drift",
+		e.Scope,
+		e.Version,
+		e.Name,
+		shortChecksum(e.Recorded),
+		shortChecksum(e.Expected),
+	)
+}
+
+func (e MigrationChecksumError) Is(target error) bool {
+	return target == ErrMigrationChecksumMismatch
+}
+
+// UnknownAppliedMigrationError carries one applied migration the binary does not define.
+type UnknownAppliedMigrationError struct {
+	Scope   string
+	Version int
+	Name    string
+}
+
+func (e UnknownAppliedMigrationError) Error() string {
+	return fmt.Sprintf(
+		"%s: schema_migrations records migration %d (%s) that this compozy build does not define; "+
+			"inspect it with `compozy db migrate status` before migrating",
+		e.Scope,
+		e.Version,
+		e.Name,
+	)
+}
+
+func (e UnknownAppliedMigrationError) Is(target error) bool {
+	return target == ErrUnknownAppliedMigration
+}
+
+// MigratorConfig wires one database's migration list into a Migrator.
+type MigratorConfig struct {
+	// Scope prefixes error messages, e.g. "globaldb".
+	Scope      string
+	Migrations []Migration
+	Now        func() time.Time
+	// SchemaTooNew builds the error returned when the database carries a
+	// migration newer than the binary knows.
+	SchemaTooNew func(current int, known int) error
+}
+
+// Migrator applies, reverts, and reports versioned migrations recorded in schema_migrations.
+type Migrator struct {
+	scope        string
+	migrations   []Migration
+	now          func() time.Time
+	schemaTooNew func(int, int) error
+}
+
+type appliedMigration struct {
+	name      string
+	appliedAt string
+	checksum  string
+}
+
+// NewMigrator constructs a migrator for one ordered migration list.
+func NewMigrator(cfg MigratorConfig) *Migrator {
+	scope := strings.TrimSpace(cfg.Scope)
+	if scope == "" {
+		scope = "store"
+	}
+	now := cfg.Now
+	if now == nil {
+		now = func() time.Time {
+			return time.Now().UTC()
+		}
+	}
+	m := &Migrator{
+		scope:        scope,
+		migrations:   slices.Clone(cfg.Migrations),
+		now:          now,
+		schemaTooNew: cfg.SchemaTooNew,
+	}
+	if m.schemaTooNew == nil {
+		m.schemaTooNew = func(current int, known int) error {
+			return fmt.Errorf("%s: schema too new (db=%d binary=%d)", scope, current, known)
+		}
+	}
+	return m
+}
+
+// Latest returns the newest migration version compiled into the binary.
+func (m *Migrator) Latest() int {
+	if m == nil || len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status lists every known migration plus any applied versions the binary does
+// not define. It only reads: a missing schema_migrations table reports every
+// migration pending, and a table without the checksum column reports applied
+// rows with no recorded checksum.
+func (m *Migrator) Status(ctx context.Context, db *sql.DB) ([]MigrationState, error) {
+	if err := m.validate(ctx, db); err != nil {
+		return nil, err
+	}
+	applied, err := m.loadApplied(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(m.migrations)+len(applied))
+	for _, item := range m.migrations {
+		state := MigrationState{
+			Version:    item.Version,
+			Name:       strings.TrimSpace(item.Name),
+			Checksum:   item.Checksum(),
+			Known:      true,
+			Reversible: len(item.Down) > 0,
+		}
+		if row, ok := applied[item.Version]; ok {
+			state.Applied = true
+			state.AppliedChecksum = row.checksum
+			state.AppliedAt = parseMigrationTimestamp(row.appliedAt)
+		}
+		states = append(states, state)
+	}
+	for version, row := range applied {
+		if m.find(version) >= 0 {
+			continue
+		}
+		states = append(states, MigrationState{
+			Version:         version,
+			Name:            row.name,
+			AppliedChecksum: row.checksum,
+			AppliedAt:       parseMigrationTimestamp(row.appliedAt),
+			Applied:         true,
+		})
+	}
+	slices.SortFunc(states, func(a, b MigrationState) int { return a.Version - b.Version })
+	return states, nil
+}
+
+// Verify rejects databases whose applied migrations drifted from the binary.
+func (m *Migrator) Verify(ctx context.Context, db *sql.DB) error {
+	states, err := m.Status(ctx, db)
+	if err != nil {
+		return err
+	}
+	return m.verifyStates(states)
+}
+
+// Up applies every pending migration after verifying the recorded history.
+func (m *Migrator) Up(ctx context.Context, db *sql.DB) error {
+	return m.MigrateTo(ctx, db, m.Latest())
+}
+
+// Down reverts the newest steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, db *sql.DB, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("%s: rollback steps must be positive (got %d)", m.scope, steps)
+	}
+	states, err := m.Status(ctx, db)
+	if err != nil {
+		return err
+	}
+	appliedVersions := make([]int, 0, len(states))
+	for _, state := range states {
+		if state.Applied {
+			appliedVersions = append(appliedVersions, state.Version)
+		}
+	}
+	target := 0
+	if steps < len(appliedVersions) {
+		target = appliedVersions[len(appliedVersions)-steps-1]
+	}
+	return m.MigrateTo(ctx, db, target)
+}
+
+// MigrateTo applies or reverts migrations until target is the newest applied
+// version. Target 0 reverts every migration.
+func (m *Migrator) MigrateTo(ctx context.Context, db *sql.DB, target int) error {
+	if target != 0 && m.find(target) < 0 {
+		return fmt.Errorf("%w: %s has no migration %d", ErrUnknownMigrationVersion, m.scope, target)
+	}
+	if err := m.prepare(ctx, db); err != nil {
+		return err
+	}
+	states, err := m.Status(ctx, db)
+	if err != nil {
+		return err
+	}
+	if highest := highestAppliedVersion(states); highest > m.Latest() {
+		return m.schemaTooNew(highest, m.Latest())
+	}
+	if err := m.rejectUnknownApplied(states); err != nil {
+		return err
+	}
+	if err := m.verifyStates(states); err != nil {
+		return err
+	}
+	if err := m.backfillChecksums(ctx, db, states); err != nil {
+		return err
+	}
+
+	for i := len(states) - 1; i >= 0; i-- {
+		state := states[i]
+		if state.Applied && state.Version > target {
+			if err := m.revert(ctx, db, m.migrations[m.find(state.Version)]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, state := range states {
+		if !state.Applied && state.Version <= target {
+			if err := m.apply(ctx, db, m.migrations[m.find(state.Version)]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) validate(ctx context.Context, db *sql.DB) error {
+	if ctx == nil {
+		return fmt.Errorf("%s: migrate context is required", m.scope)
+	}
+	if db == nil {
+		return fmt.Errorf("%s: migrate database is required", m.scope)
+	}
+	for i := 1; i < len(m.migrations); i++ {
+		if m.migrations[i].Version <= m.migrations[i-1].Version {
+			return fmt.Errorf("%s: migration versions must be strictly ascending", m.scope)
+		}
+	}
+	return nil
+}
+
+// prepare creates or upgrades schema_migrations before migrations change the schema.
+func (m *Migrator) prepare(ctx context.Context, db *sql.DB) error {
+	if err := m.validate(ctx, db); err != nil {
+		return err
+	}
+	if err := EnsureSchema(ctx, db, []string{
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TEXT NOT NULL,
+			checksum   TEXT NOT NULL DEFAULT ''
+		);`,
+	}); err != nil {
+		return fmt.Errorf("%s: ensure schema migrations table: %w", m.scope, err)
+	}
+
+	hasChecksum, err := tableHasColumn(ctx, db, "schema_migrations", "checksum")
+	if err != nil {
+		return fmt.Errorf("%s: inspect schema migrations table: %w", m.scope, err)
+	}
+	if !hasChecksum {
+		// Tables created before checksums were tracked get the column added in place.
+		if _, err := db.ExecContext(
+			ctx,
+			`ALTER TABLE schema_migrations ADD COLUMN checksum TEXT NOT NULL DEFAULT ''`,
+		); err != nil {
+			return fmt.Errorf("%s: add schema migrations checksum column: %w", m.scope, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) loadApplied(ctx context.Context, db *sql.DB) (map[int]appliedMigration, error) {
+	hasTable, err := sqliteTableExists(ctx, db, "schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("%s: inspect schema migrations table: %w", m.scope, err)
+	}
+	if !hasTable {
+		return map[int]appliedMigration{}, nil
+	}
+	hasChecksum, err := tableHasColumn(ctx, db, "schema_migrations", "checksum")
+	if err != nil {
+		return nil, fmt.Errorf("%s: inspect schema migrations table: %w", m.scope, err)
+	}
+	checksumColumn := "checksum"
+	if !hasChecksum {
+		checksumColumn = "''"
+	}
+
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT version, name, applied_at, `+checksumColumn+` FROM schema_migrations ORDER BY version ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query schema migrations: %w", m.scope, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var (
+			version int
+			row     appliedMigration
+		)
+		if err := rows.Scan(&version, &row.name, &row.appliedAt, &row.checksum); err != nil {
+			return nil, fmt.Errorf("%s: scan schema migration: %w", m.scope, err)
+		}
+		applied[version] = row
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate schema migrations: %w", m.scope, err)
+	}
+	return applied, nil
+}
+
+func (m *Migrator) verifyStates(states []MigrationState) error {
+	for _, state := range states {
+		if state.Drifted() {
+			return MigrationChecksumError{
+				Scope:    m.scope,
+				Version:  state.Version,
+				Name:     state.Name,
+				Recorded: state.AppliedChecksum,
+				Expected: state.Checksum,
+			}
+		}
+	}
+	return nil
+}
+
+// rejectUnknownApplied stops migrations from indexing an applied version the
+// binary has no definition for, such as a hand-inserted history row.
+func (m *Migrator) rejectUnknownApplied(states []MigrationState) error {
+	for _, state := range states {
+		if state.Applied && !state.Known {
+			return UnknownAppliedMigrationError{Scope: m.scope, Version: state.Version, Name: state.Name}
+		}
+	}
+	return nil
+}
+
+// backfillChecksums records checksums for rows written before they were tracked.
+func (m *Migrator) backfillChecksums(ctx context.Context, db *sql.DB, states []MigrationState) error {
+	for _, state := range states {
+		if !state.Applied || !state.Known || state.AppliedChecksum != "" {
+			continue
+		}
+		if _, err := db.ExecContext(
+			ctx,
+			`UPDATE schema_migrations SET checksum = ? WHERE version = ? AND checksum = ''`,
+			state.Checksum,
+			state.Version,
+		); err != nil {
+			return fmt.Errorf("%s: backfill migration %d checksum: %w", m.scope, state.Version, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) apply(ctx context.Context, db *sql.DB, item Migration) error {
+	return m.inTx(ctx, db, item, "apply", func(tx *sql.Tx) error {
+		if err := execMigrationStatements(ctx, tx, item.Up); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO schema_migrations (version, name, applied_at, checksum) VALUES (?, ?, ?, ?)`,
+			item.Version,
+			strings.TrimSpace(item.Name),
+			FormatTimestamp(m.now()),
+			item.Checksum(),
+		)
+		return err
+	})
+}
+
+func (m *Migrator) revert(ctx context.Context, db *sql.DB, item Migration) error {
+	if len(item.Down) == 0 {
+		return fmt.Errorf(
+			"%w: %s migration %d (%s) has no down statements",
+			ErrMigrationIrreversible,
+			m.scope,
+			item.Version,
+			strings.TrimSpace(item.Name),
+		)
+	}
+	return m.inTx(ctx, db, item, "revert", func(tx *sql.Tx) error {
+		if err := execMigrationStatements(ctx, tx, item.Down); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, item.Version)
+		return err
+	})
+}
+
+func (m *Migrator) inTx(
+	ctx context.Context,
+	db *sql.DB,
+	item Migration,
+	action string,
+	fn func(*sql.Tx) error,
+) (retErr error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin %s migration %d: %w", m.scope, action, item.Version, err)
+	}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			retErr = errors.Join(
+				retErr,
+				fmt.Errorf("%s: rollback migration %d: %w", m.scope, item.Version, rollbackErr),
+			)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return fmt.Errorf(
+			"%s: %s migration %d (%s): %w",
+			m.scope,
+			action,
+			item.Version,
+			strings.TrimSpace(item.Name),
+			err,
+		)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit migration %d: %w", m.scope, item.Version, err)
+	}
+	committed = true
+	return nil
+}
+
+func (m *Migrator) find(version int) int {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func execMigrationStatements(ctx context.Context, tx *sql.Tx, statements []string) error {
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func highestAppliedVersion(states []MigrationState) int {
+	highest := 0
+	for _, state := range states {
+		if state.Applied && state.Version > highest {
+			highest = state.Version
+		}
+	}
+	return highest
+}
+
+func sqliteTableExists(ctx context.Context, db *sql.DB, table string) (bool, error) {
+	var count int
+	if err := db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(1) FROM sqlite_master WHERE type = 'table' AND name = ?`,
+		table,
+	).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func tableHasColumn(ctx context.Context, db *sql.DB, table string, column string) (bool, error) {
+	var count int
+	if err := db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(1) FROM pragma_table_info(?) WHERE name = ?`,
+		table,
+		column,
+	).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func parseMigrationTimestamp(value string) time.Time {
+	parsed, err := ParseTimestamp(value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+func shortChecksum(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	if len(value) > 12 {
+		return value[:12]
+	}
+	return value
+}
@@ -0,0 +1,379 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testMigrations = []Migration{
+	{
+		Version: 1,
+		Name:    "items",
+		Up:      []string{`CREATE TABLE items (id INTEGER PRIMARY KEY);`},
+		Down:    []string{`DROP TABLE items;`},
+	},
+	{
+		Version: 2,
+		Name:    "items_label",
+		Up:      []string{`ALTER TABLE items ADD COLUMN label TEXT NOT NULL DEFAULT '';`},
+		Down:    []string{`ALTER TABLE items DROP COLUMN label;`},
+	},
+	{
+		Version: 3,
+		Name:    "notes",
+		Up:      []string{`CREATE TABLE notes (id INTEGER PRIMARY KEY);`},
+		Down:    []string{`DROP TABLE notes;`},
+	},
+}
+
+func TestMigrationChecksum(t *testing.T) {
+	t.Parallel()
+
+	base := Migration{Version: 1, Name: "items", Up: []string{"CREATE TABLE items (id INTEGER)"}}
+	tests := []struct {
+		name  string
+		other Migration
+		same  bool
+	}{
+		{
+			name:  "whitespace changes keep the checksum",
+			other: Migration{Version: 1, Name: " items ", Up: []string{"CREATE  TABLE items\n\t(id INTEGER)"}},
+			same:  true,
+		},
+		{
+			name:  "down statements are excluded",
+			other: Migration{Version: 1, Name: "items", Up: base.Up, Down: []string{"DROP TABLE items"}},
+			same:  true,
+		},
+		{
+			name:  "statement edits change the checksum",
+			other: Migration{Version: 1, Name: "items", Up: []string{"CREATE TABLE items (id TEXT)"}},
+		},
+		{
+			name:  "version changes the checksum",
+			other: Migration{Version: 2, Name: "items", Up: base.Up},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := base.Checksum() == tt.other.Checksum(); got != tt.same {
+				t.Fatalf("checksum equality = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
+
+func TestMigratorUpDownAndStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	migrator := newTestMigrator(testMigrations)
+
+	if err := migrator.Up(ctx, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	assertAppliedVersions(t, migrator, db, 1, 2, 3)
+
+	if err := migrator.Down(ctx, db, 2); err != nil {
+		t.Fatalf("Down(2) error = %v", err)
+	}
+	assertAppliedVersions(t, migrator, db, 1)
+	if tableExists(t, db, "notes") {
+		t.Fatal("notes table survived rollback")
+	}
+
+	if err := migrator.MigrateTo(ctx, db, 2); err != nil {
+		t.Fatalf("MigrateTo(2) error = %v", err)
+	}
+	assertAppliedVersions(t, migrator, db, 1, 2)
+
+	if err := migrator.MigrateTo(ctx, db, 7); !errors.Is(err, ErrUnknownMigrationVersion) {
+		t.Fatalf("MigrateTo(7) error = %v, want ErrUnknownMigrationVersion", err)
+	}
+	if err := migrator.Down(ctx, db, 0); err == nil {
+		t.Fatal("Down(0) error = nil, want non-nil")
+	}
+}
+
+func TestMigratorRejectsIrreversibleRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	items := []Migration{testMigrations[0], {Version: 2, Name: "one_way", Up: []string{`DELETE FROM items;`}}}
+	migrator := newTestMigrator(items)
+	if err := migrator.Up(ctx, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	if err := migrator.Down(ctx, db, 1); !errors.Is(err, ErrMigrationIrreversible) {
+		t.Fatalf("Down(1) error = %v, want ErrMigrationIrreversible", err)
+	}
+	assertAppliedVersions(t, migrator, db, 1, 2)
+}
+
+func TestMigratorDetectsChecksumDrift(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	if err := newTestMigrator(testMigrations[:1]).Up(ctx, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	edited := []Migration{{
+		Version: 1,
+		Name:    "items",
+		Up:      []string{`CREATE TABLE items (id INTEGER PRIMARY KEY, extra TEXT);`},
+		Down:    testMigrations[0].Down,
+	}}
+	migrator := newTestMigrator(edited)
+	err := migrator.Up(ctx, db)
+	if !errors.Is(err, ErrMigrationChecksumMismatch) {
+		t.Fatalf("Up() error = %v, want ErrMigrationChecksumMismatch", err)
+	}
+	var checksumErr MigrationChecksumError
+	if !errors.As(err, &checksumErr) || checksumErr.Version != 1 {
+		t.Fatalf("Up() error = %#v, want MigrationChecksumError for version 1", err)
+	}
+	if !strings.Contains(err.Error(), "compozy db migrate status") {
+		t.Fatalf("Up() error = %q, want remediation hint", err)
+	}
+	if err := migrator.Verify(ctx, db); !errors.Is(err, ErrMigrationChecksumMismatch) {
+		t.Fatalf("Verify() error = %v, want ErrMigrationChecksumMismatch", err)
+	}
+}
+
+func TestMigratorUpgradesLegacyHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	if _, err := db.ExecContext(ctx, `CREATE TABLE schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		t.Fatalf("create legacy history: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, testMigrations[0].Up[0]); err != nil {
+		t.Fatalf("apply legacy migration: %v", err)
+	}
+	if _, err := db.ExecContext(
+		ctx,
+		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (1, 'items', '2026-04-17T19:00:00Z')`,
+	); err != nil {
+		t.Fatalf("record legacy migration: %v", err)
+	}
+
+	migrator := newTestMigrator(testMigrations)
+	if err := migrator.Up(ctx, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	states, err := migrator.Status(ctx, db)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, state := range states {
+		if !state.Applied || state.AppliedChecksum != state.Checksum {
+			t.Fatalf("state %+v, want applied with backfilled checksum", state)
+		}
+	}
+}
+
+func TestMigratorStatusDoesNotWrite(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("Should report a missing history table as all pending", func(t *testing.T) {
+		t.Parallel()
+
+		db := openMigrationTestDB(t)
+		states, err := newTestMigrator(testMigrations).Status(ctx, db)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		for _, state := range states {
+			if state.Applied {
+				t.Fatalf("state %+v, want pending", state)
+			}
+		}
+		if tableExists(t, db, "schema_migrations") {
+			t.Fatal("Status() created schema_migrations, want it left missing")
+		}
+	})
+
+	t.Run("Should report legacy history without adding the checksum column", func(t *testing.T) {
+		t.Parallel()
+
+		db := openMigrationTestDB(t)
+		if _, err := db.ExecContext(ctx, `CREATE TABLE schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TEXT NOT NULL
+		)`); err != nil {
+			t.Fatalf("create legacy history: %v", err)
+		}
+		if _, err := db.ExecContext(
+			ctx,
+			`INSERT INTO schema_migrations (version, name, applied_at) VALUES (1, 'items', '2026-04-17T19:00:00Z')`,
+		); err != nil {
+			t.Fatalf("record legacy migration: %v", err)
+		}
+
+		states, err := newTestMigrator(testMigrations).Status(ctx, db)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		if first := states[0]; !first.Applied || first.AppliedChecksum != "" || first.Drifted() {
+			t.Fatalf("first state = %+v, want applied without a recorded checksum", first)
+		}
+		hasChecksum, err := tableHasColumn(ctx, db, "schema_migrations", "checksum")
+		if err != nil {
+			t.Fatalf("tableHasColumn() error = %v", err)
+		}
+		if hasChecksum {
+			t.Fatal("Status() added the checksum column, want the table left unchanged")
+		}
+	})
+}
+
+func TestMigratorRejectsSchemaTooNew(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	if err := newTestMigrator(testMigrations).Up(ctx, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	migrator := newTestMigrator(testMigrations[:2])
+	if err := migrator.Up(ctx, db); err == nil || !strings.Contains(err.Error(), "schema too new") {
+		t.Fatalf("Up() error = %v, want schema too new", err)
+	}
+	states, err := migrator.Status(ctx, db)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	last := states[len(states)-1]
+	if last.Version != 3 || last.Known || !last.Applied {
+		t.Fatalf("last state = %+v, want unknown applied version 3", last)
+	}
+}
+
+func TestMigratorRejectsUnknownAppliedVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("Should reject a hand-inserted version below the known range", func(t *testing.T) {
+		t.Parallel()
+
+		db := openMigrationTestDB(t)
+		migrator := newTestMigrator(testMigrations)
+		if err := migrator.MigrateTo(ctx, db, 1); err != nil {
+			t.Fatalf("MigrateTo(1) error = %v", err)
+		}
+		if _, err := db.ExecContext(
+			ctx,
+			`INSERT INTO schema_migrations (version, name, applied_at) VALUES (0, 'manual', '2026-04-17T19:00:00Z')`,
+		); err != nil {
+			t.Fatalf("record manual migration: %v", err)
+		}
+
+		if err := migrator.Up(ctx, db); !errors.Is(err, ErrUnknownAppliedMigration) {
+			t.Fatalf("Up() error = %v, want ErrUnknownAppliedMigration", err)
+		}
+		if err := migrator.MigrateTo(ctx, db, 0); !errors.Is(err, ErrUnknownAppliedMigration) {
+			t.Fatalf("MigrateTo(0) error = %v, want ErrUnknownAppliedMigration", err)
+		}
+	})
+
+	t.Run("Should reject an applied version the binary dropped", func(t *testing.T) {
+		t.Parallel()
+
+		db := openMigrationTestDB(t)
+		if err := newTestMigrator(testMigrations).MigrateTo(ctx, db, 2); err != nil {
+			t.Fatalf("MigrateTo(2) error = %v", err)
+		}
+
+		gapped := newTestMigrator([]Migration{testMigrations[0], testMigrations[2]})
+		err := gapped.Up(ctx, db)
+		var unknown UnknownAppliedMigrationError
+		if !errors.As(err, &unknown) || unknown.Version != 2 {
+			t.Fatalf("Up() error = %v, want UnknownAppliedMigrationError for version 2", err)
+		}
+		if err := gapped.MigrateTo(ctx, db, 1); !errors.Is(err, ErrUnknownAppliedMigration) {
+			t.Fatalf("MigrateTo(1) error = %v, want ErrUnknownAppliedMigration", err)
+		}
+	})
+}
+
+func newTestMigrator(items []Migration) *Migrator {
+	return NewMigrator(MigratorConfig{
+		Scope:      "testdb",
+		Migrations: items,
+		Now: func() time.Time {
+			return time.Date(2026, 4, 17, 19, 0, 0, 0, time.UTC)
+		},
+	})
+}
+
+func openMigrationTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := OpenSharedMemoryDatabase(context.Background(), NewID(t.Name()), nil)
+	if err != nil {
+		t.Fatalf("open migration test database: %v", err)
+	}
+	t.Cleanup(func() {
+		closeQuietly(db)
+	})
+	return db
+}
+
+func assertAppliedVersions(t *testing.T, migrator *Migrator, db *sql.DB, want ...int) {
+	t.Helper()
+
+	states, err := migrator.Status(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	got := make([]int, 0, len(states))
+	for _, state := range states {
+		if state.Applied {
+			got = append(got, state.Version)
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("applied versions = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("applied versions = %v, want %v", got, want)
+		}
+	}
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRowContext(
+		context.Background(),
+		`SELECT COUNT(1) FROM sqlite_master WHERE type = 'table' AND name = ?`,
+		name,
+	).Scan(&count); err != nil {
+		t.Fatalf("inspect sqlite_master: %v", err)
+	}
+	return count > 0
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// QueryResult is the outcome of one read-only ad hoc query.
+type QueryResult struct {
+	Columns   []string `json:"columns"`
+	Rows      [][]any  `json:"rows"`
+	Truncated bool     `json:"truncated"`
+}
+
+// QuerySQLiteReadOnly runs one SELECT-style statement against the database at
+// path on a connection that cannot write. At most maxRows rows are returned;
+// Truncated reports whether more were available. Callers bound the run time
+// through ctx.
+func QuerySQLiteReadOnly(ctx context.Context, path string, query string, maxRows int) (QueryResult, error) {
+	cleanPath := strings.TrimSpace(path)
+	cleanQuery := strings.TrimSpace(query)
+	if cleanPath == "" {
+		return QueryResult{}, errors.New("store: database path is required")
+	}
+	if cleanQuery == "" {
+		return QueryResult{}, errors.New("store: query is required")
+	}
+	if maxRows <= 0 {
+		return QueryResult{}, fmt.Errorf("store: query row limit must be positive (got %d)", maxRows)
+	}
+	if _, err := os.Stat(cleanPath); err != nil {
+		return QueryResult{}, fmt.Errorf("store: stat database %q: %w", cleanPath, err)
+	}
+
+	db, err := sql.Open(sqliteDriverName, readOnlySQLiteDSN(cleanPath))
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("store: open database %q read-only: %w", cleanPath, err)
+	}
+	defer closeQuietly(db)
+	db.SetMaxOpenConns(1)
+
+	rows, err := db.QueryContext(ctx, cleanQuery)
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("store: run query: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("store: read query columns: %w", err)
+	}
+	result := QueryResult{Columns: columns, Rows: make([][]any, 0)}
+	for rows.Next() {
+		if len(result.Rows) == maxRows {
+			result.Truncated = true
+			break
+		}
+		values := make([]any, len(columns))
+		targets := make([]any, len(columns))
+		for idx := range values {
+			targets[idx] = &values[idx]
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return QueryResult{}, fmt.Errorf("store: scan query row: %w", err)
+		}
+		for idx, value := range values {
+			values[idx] = queryResultValue(value)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return QueryResult{}, fmt.Errorf("store: run query: %w", err)
+	}
+	return result, nil
+}
+
+// readOnlySQLiteDSN opens the file read-only and sets query_only so that
+// neither the main database nor an attached one can be written.
+func readOnlySQLiteDSN(path string) string {
+	return readOnlyFileSQLiteDSN(path, true)
+}
+
+// readOnlyFileSQLiteDSN opens the file read-only. queryOnly additionally
+// blocks statements that write any file, including VACUUM INTO.
+func readOnlyFileSQLiteDSN(path string, queryOnly bool) string {
+	slashPath := filepath.ToSlash(path)
+	if filepath.IsAbs(path) && !strings.HasPrefix(slashPath, "/") {
+		slashPath = "/" + slashPath
+	}
+	query := url.Values{}
+	query.Set("mode", "ro")
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", defaultBusyTimeoutMS))
+	if queryOnly {
+		query.Add("_pragma", "query_only(1)")
+	}
+	u := url.URL{Scheme: "file", Path: slashPath, RawQuery: query.Encode()}
+	return u.String()
+}
+
+func queryResultValue(value any) any {
+	switch typed := value.(type) {
+	case []byte:
+		return string(typed)
+	case time.Time:
+		return typed.UTC().Format(timestampLayout)
+	default:
+		return typed
+	}
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestQuerySQLiteReadOnly(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "live.db")
+	db := openBackupTestDatabase(t, dbPath)
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		execBackupTestStatement(t, db, "INSERT INTO items(name) VALUES ('"+name+"')")
+	}
+	closeQuietly(db)
+
+	t.Run("Should return columns and rows up to the row limit", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := QuerySQLiteReadOnly(context.Background(), dbPath, "SELECT name FROM items ORDER BY name", 2)
+		if err != nil {
+			t.Fatalf("QuerySQLiteReadOnly() error = %v", err)
+		}
+		if len(result.Columns) != 1 || result.Columns[0] != "name" {
+			t.Fatalf("columns = %#v, want [name]", result.Columns)
+		}
+		if len(result.Rows) != 2 || result.Rows[0][0] != "alpha" || result.Rows[1][0] != "beta" {
+			t.Fatalf("rows = %#v, want alpha and beta", result.Rows)
+		}
+		if !result.Truncated {
+			t.Fatal("Truncated = false, want true")
+		}
+	})
+
+	t.Run("Should reject statements that write", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := QuerySQLiteReadOnly(
+			context.Background(),
+			dbPath,
+			"DELETE FROM items RETURNING name",
+			10,
+		); err == nil {
+			t.Fatal("QuerySQLiteReadOnly(DELETE) error = nil, want read-only rejection")
+		}
+		result, err := QuerySQLiteReadOnly(context.Background(), dbPath, "SELECT COUNT(*) AS total FROM items", 10)
+		if err != nil {
+			t.Fatalf("QuerySQLiteReadOnly(count) error = %v", err)
+		}
+		if got := result.Rows[0][0]; got != int64(3) {
+			t.Fatalf("row count = %#v, want 3", got)
+		}
+	})
+}
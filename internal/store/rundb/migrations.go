@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/compozy/compozy/internal/store"
@@ -15,6 +14,7 @@ type migration struct {
 	version    int
 	name       string
 	statements []string
+	down       []string
 }
 
 var migrations = []migration{
@@ -80,6 +80,14 @@ var migrations = []migration{
 			);`,
 			`CREATE INDEX IF NOT EXISTS idx_artifact_sync_log_path ON artifact_sync_log(relative_path);`,
 		},
+		down: []string{
+			`DROP TABLE IF EXISTS artifact_sync_log;`,
+			`DROP TABLE IF EXISTS token_usage;`,
+			`DROP TABLE IF EXISTS hook_runs;`,
+			`DROP TABLE IF EXISTS transcript_messages;`,
+			`DROP TABLE IF EXISTS job_state;`,
+			`DROP TABLE IF EXISTS events;`,
+		},
 	},
 	{
 		version: 2,
@@ -92,6 +100,15 @@ var migrations = []migration{
 			`DROP INDEX IF EXISTS idx_transcript_messages_timestamp;`,
 			`DROP INDEX IF EXISTS idx_artifact_sync_log_path;`,
 		},
+		down: []string{
+			`CREATE INDEX IF NOT EXISTS idx_events_kind ON events(event_kind);`,
+			`CREATE INDEX IF NOT EXISTS idx_events_timestamp ON events(timestamp);`,
+			`CREATE INDEX IF NOT EXISTS idx_events_job_id ON events(job_id);`,
+			`CREATE INDEX IF NOT EXISTS idx_job_state_status ON job_state(status);`,
+			`CREATE INDEX IF NOT EXISTS idx_transcript_messages_timestamp
+				ON transcript_messages(timestamp);`,
+			`CREATE INDEX IF NOT EXISTS idx_artifact_sync_log_path ON artifact_sync_log(relative_path);`,
+		},
 	},
 	{
 		version: 3,
@@ -107,17 +124,12 @@ var migrations = []migration{
 				updated_at                TEXT NOT NULL
 			);`,
 		},
+		down: []string{
+			`DROP TABLE IF EXISTS run_integrity;`,
+		},
 	},
 }
 
-var migrationTableStatements = []string{
-	`CREATE TABLE IF NOT EXISTS schema_migrations (
-		version    INTEGER PRIMARY KEY,
-		name       TEXT NOT NULL,
-		applied_at TEXT NOT NULL
-	);`,
-}
-
 // ErrSchemaTooNew reports that a database carries a migration newer than this binary understands.
 var ErrSchemaTooNew = errors.New("rundb: schema too new")
 
@@ -135,122 +147,32 @@ func (e SchemaTooNewError) Is(target error) bool {
 	return target == ErrSchemaTooNew
 }
 
-func applyMigrations(ctx context.Context, db *sql.DB, now func() time.Time) error {
-	if ctx == nil {
-		return errors.New("rundb: migrate context is required")
-	}
-	if db == nil {
-		return errors.New("rundb: migrate database is required")
-	}
-	if now == nil {
-		now = func() time.Time {
-			return time.Now().UTC()
-		}
-	}
-
-	if err := store.EnsureSchema(ctx, db, migrationTableStatements); err != nil {
-		return fmt.Errorf("rundb: ensure schema migrations table: %w", err)
-	}
-
-	applied, err := loadAppliedMigrations(ctx, db)
-	if err != nil {
-		return err
-	}
-
-	latestKnown := migrations[len(migrations)-1].version
-	if applied.highestVersion > latestKnown {
-		return SchemaTooNewError{
-			CurrentVersion: applied.highestVersion,
-			KnownVersion:   latestKnown,
-		}
-	}
-
-	for _, item := range migrations {
-		if applied.versions[item.version] {
-			continue
-		}
-		if err := applyMigration(ctx, db, item, now); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-type appliedMigrationState struct {
-	versions       map[int]bool
-	highestVersion int
+// Migrator exposes the run store migrations for offline tooling such as
+// `compozy db migrate`. It does not apply anything until called.
+func Migrator() *store.Migrator {
+	return newMigrator(migrations, nil)
 }
 
-func loadAppliedMigrations(ctx context.Context, db *sql.DB) (appliedMigrationState, error) {
-	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations ORDER BY version ASC`)
-	if err != nil {
-		return appliedMigrationState{}, fmt.Errorf("rundb: query schema migrations: %w", err)
-	}
-	defer func() {
-		_ = rows.Close()
-	}()
-
-	state := appliedMigrationState{versions: make(map[int]bool)}
-	for rows.Next() {
-		var version int
-		if err := rows.Scan(&version); err != nil {
-			return appliedMigrationState{}, fmt.Errorf("rundb: scan schema migration: %w", err)
-		}
-		state.versions[version] = true
-		if version > state.highestVersion {
-			state.highestVersion = version
-		}
+func newMigrator(items []migration, now func() time.Time) *store.Migrator {
+	converted := make([]store.Migration, 0, len(items))
+	for _, item := range items {
+		converted = append(converted, store.Migration{
+			Version: item.version,
+			Name:    item.name,
+			Up:      item.statements,
+			Down:    item.down,
+		})
 	}
-	if err := rows.Err(); err != nil {
-		return appliedMigrationState{}, fmt.Errorf("rundb: iterate schema migrations: %w", err)
-	}
-
-	return state, nil
+	return store.NewMigrator(store.MigratorConfig{
+		Scope:      "rundb",
+		Migrations: converted,
+		Now:        now,
+		SchemaTooNew: func(current int, known int) error {
+			return SchemaTooNewError{CurrentVersion: current, KnownVersion: known}
+		},
+	})
 }
 
-func applyMigration(ctx context.Context, db *sql.DB, item migration, now func() time.Time) (retErr error) {
-	tx, err := db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("rundb: begin migration %d: %w", item.version, err)
-	}
-
-	committed := false
-	defer func() {
-		if !committed {
-			if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
-				retErr = errors.Join(
-					retErr,
-					fmt.Errorf("rundb: rollback migration %d: %w", item.version, rollbackErr),
-				)
-			}
-		}
-	}()
-
-	for _, stmt := range item.statements {
-		if _, err := tx.ExecContext(ctx, stmt); err != nil {
-			return fmt.Errorf(
-				"rundb: apply migration %d (%s): %w",
-				item.version,
-				strings.TrimSpace(item.name),
-				err,
-			)
-		}
-	}
-
-	if _, err := tx.ExecContext(
-		ctx,
-		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
-		item.version,
-		strings.TrimSpace(item.name),
-		store.FormatTimestamp(now()),
-	); err != nil {
-		return fmt.Errorf("rundb: record migration %d: %w", item.version, err)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("rundb: commit migration %d: %w", item.version, err)
-	}
-	committed = true
-	return nil
+func applyMigrations(ctx context.Context, db *sql.DB, now func() time.Time) error {
+	return newMigrator(migrations, now).Up(ctx, db)
 }
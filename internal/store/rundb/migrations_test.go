@@ -99,7 +99,7 @@ func TestApplyMigrationsRejectsSchemaTooNew(t *testing.T) {
 		context.Background(),
 		filepath.Join(t.TempDir(), "future-run", "run.db"),
 		func(ctx context.Context, db *sql.DB) error {
-			if err := store.EnsureSchema(ctx, db, migrationTableStatements); err != nil {
+			if err := store.EnsureSchema(ctx, db, legacyMigrationTableStatements); err != nil {
 				return err
 			}
 			_, err := db.ExecContext(
@@ -175,7 +175,7 @@ func TestApplyMigrationReturnsStatementErrors(t *testing.T) {
 		context.Background(),
 		filepath.Join(t.TempDir(), "broken-run", "run.db"),
 		func(ctx context.Context, db *sql.DB) error {
-			return store.EnsureSchema(ctx, db, migrationTableStatements)
+			return store.EnsureSchema(ctx, db, legacyMigrationTableStatements)
 		},
 	)
 	if err != nil {
@@ -185,18 +185,28 @@ func TestApplyMigrationReturnsStatementErrors(t *testing.T) {
 		_ = sqlDB.Close()
 	}()
 
-	err = applyMigration(context.Background(), sqlDB, migration{
+	err = newMigrator([]migration{{
 		version:    2,
 		name:       "broken",
 		statements: []string{"CREATE TABL definitely_invalid ("},
-	}, func() time.Time {
+	}}, func() time.Time {
 		return time.Date(2026, 4, 17, 19, 15, 0, 0, time.UTC)
-	})
+	}).Up(context.Background(), sqlDB)
 	if err == nil {
 		t.Fatal("applyMigration(broken) error = nil, want non-nil")
 	}
 }
 
+// legacyMigrationTableStatements is the schema_migrations layout written before
+// checksums were recorded.
+var legacyMigrationTableStatements = []string{
+	`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	);`,
+}
+
 type migrationRow struct {
 	Version   int
 	Name      string
@@ -268,3 +278,31 @@ func loadSchemaSnapshot(t *testing.T, sqlDB *sql.DB) map[string]string {
 
 	return snapshot
 }
+
+func TestMigrationsRoundTripThroughEveryDownStep(t *testing.T) {
+	t.Parallel()
+
+	db := openTestRunDB(t, "run-migrations-roundtrip")
+	defer func() {
+		_ = db.Close()
+	}()
+
+	ctx := context.Background()
+	migrator := newMigrator(migrations, db.now)
+	want := loadSchemaSnapshot(t, db.db)
+	for i := len(migrations) - 1; i >= 0; i-- {
+		target := 0
+		if i > 0 {
+			target = migrations[i-1].version
+		}
+		if err := migrator.MigrateTo(ctx, db.db, target); err != nil {
+			t.Fatalf("MigrateTo(%d): %v", target, err)
+		}
+		if err := migrator.Up(ctx, db.db); err != nil {
+			t.Fatalf("Up() after MigrateTo(%d): %v", target, err)
+		}
+		if got := loadSchemaSnapshot(t, db.db); !reflect.DeepEqual(got, want) {
+			t.Fatalf("schema after down to %d and up differs\nwant: %#v\ngot:  %#v", target, want, got)
+		}
+	}
+}
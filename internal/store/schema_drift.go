@@ -0,0 +1,278 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SchemaDriftKind classifies one difference between a live and an expected schema.
+type SchemaDriftKind string
+
+const (
+	// SchemaDriftUnexpected marks an object present live but absent from the migrations.
+	SchemaDriftUnexpected SchemaDriftKind = "unexpected"
+	// SchemaDriftMissing marks an object the migrations create that the live schema lacks.
+	SchemaDriftMissing SchemaDriftKind = "missing"
+	// SchemaDriftChanged marks an object whose live definition differs from the migrations.
+	SchemaDriftChanged SchemaDriftKind = "changed"
+)
+
+// SchemaDrift describes one table, column, index, view, or trigger that differs
+// from the schema the applied migrations produce.
+type SchemaDrift struct {
+	Kind   SchemaDriftKind `json:"kind"`
+	Object string          `json:"object"`
+	Name   string          `json:"name"`
+	Detail string          `json:"detail,omitempty"`
+}
+
+func (d SchemaDrift) String() string {
+	if d.Detail == "" {
+		return fmt.Sprintf("%s %s %s", d.Kind, d.Object, d.Name)
+	}
+	return fmt.Sprintf("%s %s %s: %s", d.Kind, d.Object, d.Name, d.Detail)
+}
+
+// SchemaDriftReport lists every difference found for one database.
+type SchemaDriftReport struct {
+	Scope   string        `json:"scope"`
+	Version int           `json:"version"`
+	Drifts  []SchemaDrift `json:"drifts"`
+}
+
+// Clean reports whether the live schema matches the applied migrations.
+func (r SchemaDriftReport) Clean() bool {
+	return len(r.Drifts) == 0
+}
+
+type schemaObject struct {
+	kind    string
+	name    string
+	sql     string
+	columns map[string]schemaColumn
+}
+
+type schemaColumn struct {
+	declType     string
+	notNull      bool
+	defaultValue string
+	primaryKey   int
+}
+
+func (c schemaColumn) String() string {
+	parts := []string{strings.ToUpper(strings.TrimSpace(c.declType))}
+	if c.notNull {
+		parts = append(parts, "NOT NULL")
+	}
+	if c.defaultValue != "" {
+		parts = append(parts, "DEFAULT "+c.defaultValue)
+	}
+	if c.primaryKey > 0 {
+		parts = append(parts, "PRIMARY KEY")
+	}
+	return strings.Join(parts, " ")
+}
+
+// CheckSchemaDrift compares the live schema against the schema produced by
+// replaying the applied migrations into a scratch in-memory database. Manual
+// hotfixes show up as unexpected or changed objects instead of surfacing later
+// as confusing query failures.
+func (m *Migrator) CheckSchemaDrift(ctx context.Context, db *sql.DB) (SchemaDriftReport, error) {
+	states, err := m.Status(ctx, db)
+	if err != nil {
+		return SchemaDriftReport{}, err
+	}
+	if highest := highestAppliedVersion(states); highest > m.Latest() {
+		return SchemaDriftReport{}, m.schemaTooNew(highest, m.Latest())
+	}
+	if err := m.verifyStates(states); err != nil {
+		return SchemaDriftReport{}, err
+	}
+
+	expectedDB, err := OpenSharedMemoryDatabase(ctx, NewID(m.scope+"-expected-schema"), nil)
+	if err != nil {
+		return SchemaDriftReport{}, fmt.Errorf("%s: open expected schema database: %w", m.scope, err)
+	}
+	defer closeQuietly(expectedDB)
+
+	if err := m.prepare(ctx, expectedDB); err != nil {
+		return SchemaDriftReport{}, err
+	}
+	report := SchemaDriftReport{Scope: m.scope, Version: highestAppliedVersion(states)}
+	var unknownApplied []SchemaDrift
+	for _, state := range states {
+		if !state.Applied {
+			continue
+		}
+		if !state.Known {
+			// Without a definition there is nothing to replay; the row itself is the drift.
+			unknownApplied = append(unknownApplied, SchemaDrift{
+				Kind:   SchemaDriftUnexpected,
+				Object: "migration",
+				Name:   strconv.Itoa(state.Version),
+				Detail: fmt.Sprintf("applied migration %q is not defined by this build", state.Name),
+			})
+			continue
+		}
+		if err := m.apply(ctx, expectedDB, m.migrations[m.find(state.Version)]); err != nil {
+			return SchemaDriftReport{}, fmt.Errorf("%s: build expected schema: %w", m.scope, err)
+		}
+	}
+
+	live, err := loadSchemaObjects(ctx, db)
+	if err != nil {
+		return SchemaDriftReport{}, fmt.Errorf("%s: inspect live schema: %w", m.scope, err)
+	}
+	expected, err := loadSchemaObjects(ctx, expectedDB)
+	if err != nil {
+		return SchemaDriftReport{}, fmt.Errorf("%s: inspect expected schema: %w", m.scope, err)
+	}
+	report.Drifts = append(unknownApplied, diffSchemaObjects(live, expected)...)
+	return report, nil
+}
+
+func loadSchemaObjects(ctx context.Context, db *sql.DB) (map[string]schemaObject, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT type, name, COALESCE(sql, '')
+		 FROM sqlite_master
+		 WHERE name NOT LIKE 'sqlite_%'
+		 ORDER BY type ASC, name ASC`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	objects := make(map[string]schemaObject)
+	for rows.Next() {
+		var object schemaObject
+		if err := rows.Scan(&object.kind, &object.name, &object.sql); err != nil {
+			return nil, err
+		}
+		objects[object.kind+":"+object.name] = object
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	for key, object := range objects {
+		if object.kind != "table" {
+			continue
+		}
+		columns, err := loadSchemaColumns(ctx, db, object.name)
+		if err != nil {
+			return nil, fmt.Errorf("inspect table %s: %w", object.name, err)
+		}
+		object.columns = columns
+		objects[key] = object
+	}
+	return objects, nil
+}
+
+func loadSchemaColumns(ctx context.Context, db *sql.DB, table string) (map[string]schemaColumn, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT name, type, "notnull", COALESCE(dflt_value, ''), pk FROM pragma_table_info(?)`,
+		table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns := make(map[string]schemaColumn)
+	for rows.Next() {
+		var (
+			name   string
+			column schemaColumn
+		)
+		if err := rows.Scan(&name, &column.declType, &column.notNull, &column.defaultValue, &column.primaryKey); err != nil {
+			return nil, err
+		}
+		columns[name] = column
+	}
+	return columns, rows.Err()
+}
+
+func diffSchemaObjects(live map[string]schemaObject, expected map[string]schemaObject) []SchemaDrift {
+	drifts := make([]SchemaDrift, 0)
+	for key, want := range expected {
+		got, ok := live[key]
+		if !ok {
+			drifts = append(drifts, SchemaDrift{Kind: SchemaDriftMissing, Object: want.kind, Name: want.name})
+			continue
+		}
+		if want.kind == "table" {
+			drifts = append(drifts, diffSchemaColumns(want.name, got.columns, want.columns)...)
+			continue
+		}
+		if normalizeSchemaSQL(got.sql) != normalizeSchemaSQL(want.sql) {
+			drifts = append(drifts, SchemaDrift{
+				Kind:   SchemaDriftChanged,
+				Object: want.kind,
+				Name:   want.name,
+				Detail: fmt.Sprintf("live definition %q", strings.Join(strings.Fields(got.sql), " ")),
+			})
+		}
+	}
+	for key, got := range live {
+		if _, ok := expected[key]; !ok {
+			drifts = append(drifts, SchemaDrift{Kind: SchemaDriftUnexpected, Object: got.kind, Name: got.name})
+		}
+	}
+
+	slices.SortFunc(drifts, func(a, b SchemaDrift) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Object, b.Object)
+	})
+	return drifts
+}
+
+func diffSchemaColumns(table string, live map[string]schemaColumn, expected map[string]schemaColumn) []SchemaDrift {
+	drifts := make([]SchemaDrift, 0)
+	for name, want := range expected {
+		got, ok := live[name]
+		switch {
+		case !ok:
+			drifts = append(drifts, SchemaDrift{Kind: SchemaDriftMissing, Object: "column", Name: table + "." + name})
+		case got != want:
+			drifts = append(drifts, SchemaDrift{
+				Kind:   SchemaDriftChanged,
+				Object: "column",
+				Name:   table + "." + name,
+				Detail: fmt.Sprintf("live %q, expected %q", got.String(), want.String()),
+			})
+		}
+	}
+	for name, got := range live {
+		if _, ok := expected[name]; !ok {
+			drifts = append(drifts, SchemaDrift{
+				Kind:   SchemaDriftUnexpected,
+				Object: "column",
+				Name:   table + "." + name,
+				Detail: got.String(),
+			})
+		}
+	}
+	return drifts
+}
+
+func normalizeSchemaSQL(value string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	normalized = strings.ReplaceAll(normalized, " if not exists ", " ")
+	return strings.TrimSuffix(normalized, ";")
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMigratorCheckSchemaDrift(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		hotfix []string
+		want   []SchemaDrift
+	}{
+		{
+			name: "migrated schema is clean",
+		},
+		{
+			name:   "manual column is unexpected",
+			hotfix: []string{`ALTER TABLE items ADD COLUMN hotfix INTEGER`},
+			want: []SchemaDrift{
+				{Kind: SchemaDriftUnexpected, Object: "column", Name: "items.hotfix", Detail: "INTEGER"},
+			},
+		},
+		{
+			name:   "manual index is unexpected",
+			hotfix: []string{`CREATE INDEX idx_items_label ON items(label)`},
+			want: []SchemaDrift{
+				{Kind: SchemaDriftUnexpected, Object: "index", Name: "idx_items_label"},
+			},
+		},
+		{
+			name:   "dropped table is missing",
+			hotfix: []string{`DROP TABLE notes`},
+			want: []SchemaDrift{
+				{Kind: SchemaDriftMissing, Object: "table", Name: "notes"},
+			},
+		},
+		{
+			name: "redefined column is changed",
+			hotfix: []string{
+				`ALTER TABLE items DROP COLUMN label`,
+				`ALTER TABLE items ADD COLUMN label TEXT`,
+			},
+			want: []SchemaDrift{
+				{
+					Kind:   SchemaDriftChanged,
+					Object: "column",
+					Name:   "items.label",
+					Detail: `live "TEXT", expected "TEXT NOT NULL DEFAULT ''"`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := openMigrationTestDB(t)
+			migrator := newTestMigrator(testMigrations)
+			if err := migrator.Up(ctx, db); err != nil {
+				t.Fatalf("Up() error = %v", err)
+			}
+			for _, stmt := range tt.hotfix {
+				if _, err := db.ExecContext(ctx, stmt); err != nil {
+					t.Fatalf("apply hotfix %q: %v", stmt, err)
+				}
+			}
+
+			report, err := migrator.CheckSchemaDrift(ctx, db)
+			if err != nil {
+				t.Fatalf("CheckSchemaDrift() error = %v", err)
+			}
+			if report.Version != 3 {
+				t.Fatalf("report.Version = %d, want 3", report.Version)
+			}
+			if report.Clean() != (len(tt.want) == 0) {
+				t.Fatalf("report.Clean() = %v, drifts = %#v", report.Clean(), report.Drifts)
+			}
+			if len(report.Drifts) != len(tt.want) {
+				t.Fatalf("drifts = %#v, want %#v", report.Drifts, tt.want)
+			}
+			for i := range tt.want {
+				if report.Drifts[i] != tt.want[i] {
+					t.Fatalf("drift[%d] = %#v, want %#v", i, report.Drifts[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMigratorCheckSchemaDriftUsesAppliedVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	if err := newTestMigrator(testMigrations[:2]).Up(ctx, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	report, err := newTestMigrator(testMigrations).CheckSchemaDrift(ctx, db)
+	if err != nil {
+		t.Fatalf("CheckSchemaDrift() error = %v", err)
+	}
+	if !report.Clean() || report.Version != 2 {
+		t.Fatalf("report = %#v, want clean report at version 2", report)
+	}
+}
+
+func TestMigratorCheckSchemaDriftRejectsChecksumDrift(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	if err := newTestMigrator(testMigrations[:1]).Up(ctx, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	edited := []Migration{{Version: 1, Name: "items", Up: []string{`CREATE TABLE items (id TEXT PRIMARY KEY);`}}}
+	_, err := newTestMigrator(edited).CheckSchemaDrift(ctx, db)
+	if !errors.Is(err, ErrMigrationChecksumMismatch) {
+		t.Fatalf("CheckSchemaDrift() error = %v, want ErrMigrationChecksumMismatch", err)
+	}
+}
+
+func TestMigratorCheckSchemaDriftReportsUnknownAppliedVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openMigrationTestDB(t)
+	if err := newTestMigrator(testMigrations).MigrateTo(ctx, db, 2); err != nil {
+		t.Fatalf("MigrateTo(2) error = %v", err)
+	}
+
+	gapped := newTestMigrator([]Migration{testMigrations[0], testMigrations[2]})
+	report, err := gapped.CheckSchemaDrift(ctx, db)
+	if err != nil {
+		t.Fatalf("CheckSchemaDrift() error = %v", err)
+	}
+	found := false
+	for _, drift := range report.Drifts {
+		if drift.Kind == SchemaDriftUnexpected && drift.Object == "migration" && drift.Name == "2" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("drifts = %#v, want unexpected migration 2", report.Drifts)
+	}
+}
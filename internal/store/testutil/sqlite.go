@@ -0,0 +1,28 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/compozy/compozy/internal/store"
+)
+
+// OpenSQLiteDatabase opens an in-memory SQLite database private to one test
+// and closes it during cleanup. The name is derived from the test name plus a
+// random suffix, so parallel tests and subtests never share state. initialize
+// may apply schema statements or migrations before the handle is returned.
+func OpenSQLiteDatabase(t testing.TB, initialize func(context.Context, *sql.DB) error) *sql.DB {
+	t.Helper()
+
+	db, err := store.OpenSharedMemoryDatabase(context.Background(), store.NewID(t.Name()), initialize)
+	if err != nil {
+		t.Fatalf("open test sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close test sqlite database: %v", err)
+		}
+	})
+	return db
+}
@@ -0,0 +1,34 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestOpenSQLiteDatabaseIsolatesParallelTests(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"first", "second", "third"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := OpenSQLiteDatabase(t, func(ctx context.Context, db *sql.DB) error {
+				_, err := db.ExecContext(ctx, "CREATE TABLE items (name TEXT NOT NULL)")
+				return err
+			})
+			if _, err := db.ExecContext(ctx, "INSERT INTO items (name) VALUES (?)", name); err != nil {
+				t.Fatalf("insert item: %v", err)
+			}
+
+			var count int
+			if err := db.QueryRowContext(ctx, "SELECT COUNT(1) FROM items").Scan(&count); err != nil {
+				t.Fatalf("count items: %v", err)
+			}
+			if count != 1 {
+				t.Fatalf("item count = %d, want 1 (database leaked across tests)", count)
+			}
+		})
+	}
+}
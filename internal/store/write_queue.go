@@ -0,0 +1,192 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+const (
+	defaultWriteQueueMaxWait       = 30 * time.Second
+	defaultWriteQueueRetryAttempts = 5
+	defaultWriteQueueRetryInitial  = 25 * time.Millisecond
+	defaultWriteQueueRetryMax      = time.Second
+)
+
+// ErrWriteQueueTimeout reports that a writer gave up waiting for the write slot.
+var ErrWriteQueueTimeout = errors.New("store: timed out waiting for sqlite write slot")
+
+// WriteQueueStats captures the current depth and lifetime counters of a WriteQueue.
+type WriteQueueStats struct {
+	// Depth counts writers that are waiting for or holding the write slot.
+	Depth int64
+	// BusyRetries counts write attempts retried after SQLITE_BUSY or SQLITE_LOCKED.
+	BusyRetries int64
+	// WaitTimeouts counts writers rejected because the bounded wait elapsed.
+	WaitTimeouts int64
+}
+
+// WriteQueue serializes writers against one SQLite database so bursts queue in
+// process instead of racing for the WAL lock, and retries transient busy
+// failures with capped exponential backoff.
+type WriteQueue struct {
+	slot          chan struct{}
+	maxWait       time.Duration
+	retryAttempts int
+	retryInitial  time.Duration
+	retryMax      time.Duration
+	sleep         func(context.Context, time.Duration) error
+
+	depth        atomic.Int64
+	busyRetries  atomic.Int64
+	waitTimeouts atomic.Int64
+}
+
+// WriteQueueOption customizes a WriteQueue.
+type WriteQueueOption func(*WriteQueue)
+
+// WithWriteQueueMaxWait bounds how long a writer may wait for the write slot.
+// Non-positive values keep the default.
+func WithWriteQueueMaxWait(wait time.Duration) WriteQueueOption {
+	return func(q *WriteQueue) {
+		if wait > 0 {
+			q.maxWait = wait
+		}
+	}
+}
+
+// WithWriteQueueRetry configures busy retries. attempts counts retries after the
+// first try; zero disables retrying.
+func WithWriteQueueRetry(attempts int, initial time.Duration, maxBackoff time.Duration) WriteQueueOption {
+	return func(q *WriteQueue) {
+		if attempts >= 0 {
+			q.retryAttempts = attempts
+		}
+		if initial > 0 {
+			q.retryInitial = initial
+		}
+		if maxBackoff > 0 {
+			q.retryMax = maxBackoff
+		}
+	}
+}
+
+// NewWriteQueue constructs a single-writer queue.
+func NewWriteQueue(opts ...WriteQueueOption) *WriteQueue {
+	q := &WriteQueue{
+		slot:          make(chan struct{}, 1),
+		maxWait:       defaultWriteQueueMaxWait,
+		retryAttempts: defaultWriteQueueRetryAttempts,
+		retryInitial:  defaultWriteQueueRetryInitial,
+		retryMax:      defaultWriteQueueRetryMax,
+		sleep:         sleepContext,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(q)
+		}
+	}
+	if q.retryMax < q.retryInitial {
+		q.retryMax = q.retryInitial
+	}
+	return q
+}
+
+// Do runs fn while holding the write slot. fn is re-run from scratch when it
+// fails with a busy error, so it must own its whole transaction.
+func (q *WriteQueue) Do(ctx context.Context, fn func(context.Context) error) error {
+	if q == nil {
+		return fn(ctx)
+	}
+	if ctx == nil {
+		return errors.New("store: write context is required")
+	}
+
+	q.depth.Add(1)
+	defer q.depth.Add(-1)
+
+	if err := q.acquire(ctx); err != nil {
+		return err
+	}
+	defer q.release()
+
+	backoff := q.retryInitial
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !IsBusy(err) || attempt >= q.retryAttempts {
+			return err
+		}
+		q.busyRetries.Add(1)
+		if sleepErr := q.sleep(ctx, backoff); sleepErr != nil {
+			return errors.Join(err, sleepErr)
+		}
+		backoff = min(backoff*2, q.retryMax)
+	}
+}
+
+// Stats reports the current queue depth and lifetime counters.
+func (q *WriteQueue) Stats() WriteQueueStats {
+	if q == nil {
+		return WriteQueueStats{}
+	}
+	return WriteQueueStats{
+		Depth:        q.depth.Load(),
+		BusyRetries:  q.busyRetries.Load(),
+		WaitTimeouts: q.waitTimeouts.Load(),
+	}
+}
+
+func (q *WriteQueue) acquire(ctx context.Context) error {
+	select {
+	case q.slot <- struct{}{}:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(q.maxWait)
+	defer timer.Stop()
+	select {
+	case q.slot <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		q.waitTimeouts.Add(1)
+		return fmt.Errorf("%w after %s", ErrWriteQueueTimeout, q.maxWait)
+	}
+}
+
+func (q *WriteQueue) release() {
+	<-q.slot
+}
+
+// IsBusy reports whether err carries a transient SQLITE_BUSY or SQLITE_LOCKED result.
+func IsBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	// Extended result codes keep the primary code in the low byte.
+	switch sqliteErr.Code() & 0xff {
+	case sqlitelib.SQLITE_BUSY, sqlitelib.SQLITE_LOCKED:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,223 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsBusy(t *testing.T) {
+	t.Parallel()
+
+	busyErr := newSQLiteBusyError(t)
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil error", err: nil, want: false},
+		{name: "plain error", err: errors.New("database is locked"), want: false},
+		{name: "sqlite busy error", err: busyErr, want: true},
+		{name: "wrapped sqlite busy error", err: errors.Join(errors.New("context"), busyErr), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsBusy(tt.err); got != tt.want {
+				t.Fatalf("IsBusy(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteQueueDoRetriesBusyErrors(t *testing.T) {
+	t.Parallel()
+
+	busyErr := newSQLiteBusyError(t)
+
+	t.Run("succeeds after transient busy failures", func(t *testing.T) {
+		t.Parallel()
+		queue := newTestWriteQueue(WithWriteQueueRetry(3, time.Millisecond, time.Millisecond))
+
+		var attempts int
+		err := queue.Do(context.Background(), func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return busyErr
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		if attempts != 3 {
+			t.Fatalf("attempts = %d, want 3", attempts)
+		}
+		if got := queue.Stats().BusyRetries; got != 2 {
+			t.Fatalf("Stats().BusyRetries = %d, want 2", got)
+		}
+	})
+
+	t.Run("returns busy error once retries are exhausted", func(t *testing.T) {
+		t.Parallel()
+		queue := newTestWriteQueue(WithWriteQueueRetry(2, time.Millisecond, time.Millisecond))
+
+		var attempts int
+		err := queue.Do(context.Background(), func(context.Context) error {
+			attempts++
+			return busyErr
+		})
+		if !IsBusy(err) {
+			t.Fatalf("Do() error = %v, want busy error", err)
+		}
+		if attempts != 3 {
+			t.Fatalf("attempts = %d, want 3", attempts)
+		}
+	})
+
+	t.Run("does not retry non-busy errors", func(t *testing.T) {
+		t.Parallel()
+		queue := newTestWriteQueue()
+
+		wantErr := errors.New("constraint failed")
+		var attempts int
+		err := queue.Do(context.Background(), func(context.Context) error {
+			attempts++
+			return wantErr
+		})
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("Do() error = %v, want %v", err, wantErr)
+		}
+		if attempts != 1 {
+			t.Fatalf("attempts = %d, want 1", attempts)
+		}
+	})
+}
+
+func TestWriteQueueDoSerializesWriters(t *testing.T) {
+	t.Parallel()
+
+	queue := NewWriteQueue()
+	var (
+		active    atomic.Int64
+		maxActive atomic.Int64
+		wg        sync.WaitGroup
+	)
+	for range 8 {
+		wg.Go(func() {
+			err := queue.Do(context.Background(), func(context.Context) error {
+				current := active.Add(1)
+				for {
+					seen := maxActive.Load()
+					if current <= seen || maxActive.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				active.Add(-1)
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := maxActive.Load(); got != 1 {
+		t.Fatalf("max concurrent writers = %d, want 1", got)
+	}
+	if got := queue.Stats().Depth; got != 0 {
+		t.Fatalf("Stats().Depth = %d, want 0 after drain", got)
+	}
+}
+
+func TestWriteQueueDoBoundsWaiting(t *testing.T) {
+	t.Parallel()
+
+	queue := NewWriteQueue(WithWriteQueueMaxWait(10 * time.Millisecond))
+	holding := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- queue.Do(context.Background(), func(context.Context) error {
+			close(holding)
+			<-release
+			return nil
+		})
+	}()
+	<-holding
+
+	err := queue.Do(context.Background(), func(context.Context) error {
+		t.Fatal("queued writer ran while the slot was held")
+		return nil
+	})
+	if !errors.Is(err, ErrWriteQueueTimeout) {
+		t.Fatalf("Do() error = %v, want ErrWriteQueueTimeout", err)
+	}
+	if got := queue.Stats().WaitTimeouts; got != 1 {
+		t.Fatalf("Stats().WaitTimeouts = %d, want 1", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := queue.Do(ctx, func(context.Context) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Do(canceled) error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("holding writer error = %v", err)
+	}
+}
+
+func newTestWriteQueue(opts ...WriteQueueOption) *WriteQueue {
+	queue := NewWriteQueue(opts...)
+	queue.sleep = func(context.Context, time.Duration) error { return nil }
+	return queue
+}
+
+// newSQLiteBusyError produces a real driver SQLITE_BUSY error by writing from a
+// second handle while the first one holds the write lock.
+func newSQLiteBusyError(t *testing.T) error {
+	t.Helper()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "busy.db")
+	dsn := "file:" + filepath.ToSlash(path) + "?_pragma=busy_timeout(0)"
+
+	holder, err := sql.Open(sqliteDriverName, dsn)
+	if err != nil {
+		t.Fatalf("open holder: %v", err)
+	}
+	t.Cleanup(func() { _ = holder.Close() })
+	if _, err := holder.ExecContext(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	conn, err := holder.Conn(ctx)
+	if err != nil {
+		t.Fatalf("holder conn: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		t.Fatalf("begin immediate: %v", err)
+	}
+	t.Cleanup(func() { _, _ = conn.ExecContext(ctx, "ROLLBACK") })
+
+	writer, err := sql.Open(sqliteDriverName, dsn)
+	if err != nil {
+		t.Fatalf("open writer: %v", err)
+	}
+	t.Cleanup(func() { _ = writer.Close() })
+	_, busyErr := writer.ExecContext(ctx, "INSERT INTO items (id) VALUES (1)")
+	if busyErr == nil {
+		t.Fatal("insert while locked error = nil, want SQLITE_BUSY")
+	}
+	return busyErr
+}
@@ -52,3 +52,23 @@ type ExtensionEventPayload struct {
 	Kind      string          `json:"kind"`
 	Payload   json.RawMessage `json:"payload,omitempty"`
 }
+
+// ExtensionMetricEventKind is the reserved host.events.publish kind whose
+// payload is an ExtensionMetricPayload forwarded to the daemon metrics endpoint.
+const ExtensionMetricEventKind = "metric"
+
+// Custom metric types accepted in ExtensionMetricPayload.Type.
+const (
+	MetricTypeCounter = "counter"
+	MetricTypeGauge   = "gauge"
+)
+
+// ExtensionMetricPayload describes one custom metric sample emitted by an
+// extension. Counters add Value to the running total; gauges replace it.
+type ExtensionMetricPayload struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type,omitempty"`
+	Value  float64           `json:"value"`
+	Help   string            `json:"help,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
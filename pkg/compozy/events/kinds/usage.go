@@ -7,6 +7,8 @@ type Usage struct {
 	TotalTokens  int `json:"total_tokens,omitempty"`
 	CacheReads   int `json:"cache_reads,omitempty"`
 	CacheWrites  int `json:"cache_writes,omitempty"`
+	// ReasoningTokens is the share of OutputTokens spent on reasoning.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
 }
 
 // Add accumulates usage from another value into the receiver.
@@ -16,6 +18,7 @@ func (u *Usage) Add(other Usage) {
 	u.TotalTokens += other.TotalTokens
 	u.CacheReads += other.CacheReads
 	u.CacheWrites += other.CacheWrites
+	u.ReasoningTokens += other.ReasoningTokens
 }
 
 // Total returns the stored total or derives it from inputs and outputs.
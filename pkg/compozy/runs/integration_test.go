@@ -406,6 +406,10 @@ func (s *integrationRunService) Get(_ context.Context, runID string) (apicore.Ru
 	return apicore.Run{}, apicore.NewProblem(404, "run_not_found", "run not found", nil, nil)
 }
 
+func (s *integrationRunService) Wait(ctx context.Context, runID string) (apicore.Run, error) {
+	return s.Get(ctx, runID)
+}
+
 func (s *integrationRunService) Snapshot(_ context.Context, runID string) (apicore.RunSnapshot, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
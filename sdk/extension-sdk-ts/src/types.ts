@@ -183,6 +183,7 @@ export interface Usage {
   total_tokens?: number;
   cache_reads?: number;
   cache_writes?: number;
+  reasoning_tokens?: number;
 }
 
 /** One typed content payload in its canonical JSON form. */
@@ -477,6 +478,20 @@ export interface JobResult {
   error?: string;
 }
 
+/** Mirrors one task-scoped runtime override rule. */
+export interface TaskRuntimeRule {
+  workflow?: string;
+  id?: string;
+  type?: string;
+  complexity?: string;
+  ide?: string;
+  model?: string;
+  reasoning_effort?: string;
+  timeout?: string;
+  max_retries?: number;
+  retry_backoff_multiplier?: number;
+}
+
 /** Mirrors the run configuration payload exposed to run hooks. */
 export interface RuntimeConfig {
   workspace_root?: string;
@@ -516,6 +531,7 @@ export interface RuntimeConfig {
   timeout_ms?: number;
   max_retries?: number;
   retry_backoff_multiplier?: number;
+  task_runtime_rules?: TaskRuntimeRule[];
 }
 
 /** Mirrors the run artifact directory layout exposed to run hooks. */
@@ -8,6 +8,7 @@ import (
 	extensions "github.com/compozy/compozy/internal/core/extension"
 	"github.com/compozy/compozy/internal/core/model"
 	"github.com/compozy/compozy/internal/core/prompt"
+	"github.com/compozy/compozy/pkg/compozy/events/kinds"
 	extension "github.com/compozy/compozy/sdk/extension"
 )
 
@@ -39,6 +40,11 @@ func TestPublicHookAndHostTypesStayAlignedWithRuntime(t *testing.T) {
 			public:  extension.TaskRuntime{},
 			runtime: model.TaskRuntime{},
 		},
+		{
+			name:    "Should retain TaskRuntimeRule compatibility",
+			public:  extension.TaskRuntimeRule{},
+			runtime: model.TaskRuntimeRule{},
+		},
 		{
 			name:    "Should retain TaskRuntimeTask compatibility",
 			public:  extension.TaskRuntimeTask{},
@@ -96,6 +102,7 @@ func TestPublicHookAndHostTypesStayAlignedWithRuntime(t *testing.T) {
 			runtime: extensions.EventPublishRequest{},
 		},
 		{name: "EventPublishResult", public: extension.EventPublishResult{}, runtime: extensions.EventPublishResult{}},
+		{name: "MetricSample", public: extension.MetricSample{}, runtime: kinds.ExtensionMetricPayload{}},
 	}
 
 	for _, tc := range cases {
@@ -73,6 +73,20 @@ type EventPublishResult struct {
 	Seq uint64 `json:"seq,omitempty"`
 }
 
+// MetricEventKind is the reserved event kind PublishMetric uses for custom
+// metric samples.
+const MetricEventKind = "metric"
+
+// MetricSample describes one custom metric exported by the daemon as
+// compozy_custom_<name>. Type is "counter" (default) or "gauge".
+type MetricSample struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type,omitempty"`
+	Value  float64           `json:"value"`
+	Help   string            `json:"help,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
 // TaskFrontmatter describes the task metadata written by host.tasks.create.
 type TaskFrontmatter struct {
 	Status       string   `json:"status"`
@@ -269,6 +283,15 @@ func (c *EventsClient) Publish(
 	return callHostMethod[EventPublishResult](ctx, c.caller, "host.events.publish", req)
 }
 
+// PublishMetric emits one custom metric sample through host.events.publish.
+func (c *EventsClient) PublishMetric(ctx context.Context, sample MetricSample) (*EventPublishResult, error) {
+	payload, err := json.Marshal(sample)
+	if err != nil {
+		return nil, fmt.Errorf("host.events.publish: encode metric: %w", err)
+	}
+	return c.Publish(ctx, EventPublishRequest{Kind: MetricEventKind, Payload: payload})
+}
+
 // List enumerates the tasks inside one workflow directory.
 func (c *TasksClient) List(ctx context.Context, req TaskListRequest) ([]Task, error) {
 	if c == nil || c.caller == nil {
@@ -446,12 +446,22 @@ type Job struct {
 	IDE             string
 	Model           string
 	ReasoningEffort string
-	Prompt          []byte
-	SystemPrompt    string
-	MCPServers      []MCPServer
-	OutPromptPath   string
-	OutLog          string
-	ErrLog          string
+	// RetryPolicy is set when task runtime rules give this job its own attempt
+	// timeout or retry budget; nil jobs follow the run configuration.
+	RetryPolicy   *JobRetryPolicy
+	Prompt        []byte
+	SystemPrompt  string
+	MCPServers    []MCPServer
+	OutPromptPath string
+	OutLog        string
+	ErrLog        string
+}
+
+// JobRetryPolicy mirrors the attempt timeout and retry budget of one job.
+type JobRetryPolicy struct {
+	Timeout                time.Duration
+	MaxRetries             int
+	RetryBackoffMultiplier float64
 }
 
 // FetchConfig mirrors the review provider fetch configuration.
@@ -501,12 +511,16 @@ type TaskRuntimeTask struct {
 
 // TaskRuntimeRule mirrors one task-scoped runtime override rule.
 type TaskRuntimeRule struct {
-	ID              *string `json:"id,omitempty"`
-	Type            *string `json:"type,omitempty"`
-	Complexity      *string `json:"complexity,omitempty"`
-	IDE             *string `json:"ide,omitempty"`
-	Model           *string `json:"model,omitempty"`
-	ReasoningEffort *string `json:"reasoning_effort,omitempty"`
+	Workflow               *string  `json:"workflow,omitempty"`
+	ID                     *string  `json:"id,omitempty"`
+	Type                   *string  `json:"type,omitempty"`
+	Complexity             *string  `json:"complexity,omitempty"`
+	IDE                    *string  `json:"ide,omitempty"`
+	Model                  *string  `json:"model,omitempty"`
+	ReasoningEffort        *string  `json:"reasoning_effort,omitempty"`
+	Timeout                *string  `json:"timeout,omitempty"`
+	MaxRetries             *int     `json:"max_retries,omitempty"`
+	RetryBackoffMultiplier *float64 `json:"retry_backoff_multiplier,omitempty"`
 }
 
 // RuntimeConfig mirrors the run configuration payload exposed to run hooks.
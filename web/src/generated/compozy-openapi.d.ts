@@ -1083,6 +1083,7 @@ export interface components {
             cache_writes?: number;
             input_tokens?: number;
             output_tokens?: number;
+            reasoning_tokens?: number;
             total_tokens?: number;
         };
         ValidationSuccess: {